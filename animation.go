@@ -0,0 +1,436 @@
+package gogl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// defaultFrameDuration is how long frames are shown for if the sprite sheet doesn't say.
+const defaultFrameDuration = 100 * time.Millisecond
+
+// AnimationMode decides what happens when an animation reaches its last frame.
+type AnimationMode int
+
+const (
+	AnimationLoop     AnimationMode = iota // start again from the first frame
+	AnimationPingPong                      // play backwards to the first frame, then repeat
+	AnimationOneShot                       // stop on the last frame
+)
+
+// String returns the name of the animation mode.
+func (a AnimationMode) String() string {
+	switch a {
+	case AnimationLoop:
+		return "loop"
+	case AnimationPingPong:
+		return "ping-pong"
+	case AnimationOneShot:
+		return "one-shot"
+	default:
+		return "invalid"
+	}
+}
+
+// Frame is a frame of a sprite sheet.
+type Frame struct {
+	Name     string
+	Source   image.Rectangle // region of the sheet's image
+	Offset   image.Point     // position of the region within the frame, if it was trimmed
+	Size     image.Point     // size of the frame before it was trimmed
+	Duration time.Duration
+}
+
+// Clip is a named animation made from frames of a sprite sheet.
+type Clip struct {
+	Frames []int // indices into the sheet's frames, in the order they are played
+	Mode   AnimationMode
+}
+
+// SpriteSheet is an image containing the frames of animations.
+type SpriteSheet struct {
+	Image  *FrameBuffer
+	Frames []Frame
+	Clips  map[string]Clip
+}
+
+// LoadSpriteSheet loads a sprite sheet from an Aseprite or TexturePacker JSON file, along
+// with the image it refers to.
+func LoadSpriteSheet(path string) (*SpriteSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sheet, imagePath, err := parseSpriteSheet(data)
+	if err != nil {
+		return nil, err
+	}
+	sheet.Image, err = LoadFrameBuffer(filepath.Join(filepath.Dir(path), filepath.FromSlash(imagePath)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprite sheet image: %w", err)
+	}
+	return sheet, nil
+}
+
+// LoadSpriteSheetFS loads a sprite sheet from an Aseprite or TexturePacker JSON file in a
+// file system, such as an embed.FS, along with the image it refers to.
+func LoadSpriteSheetFS(fsys fs.FS, name string) (*SpriteSheet, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	sheet, imagePath, err := parseSpriteSheet(data)
+	if err != nil {
+		return nil, err
+	}
+	file, err := fsys.Open(path.Join(path.Dir(name), imagePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprite sheet image: %w", err)
+	}
+	defer file.Close()
+
+	sheet.Image, err = DecodeFrameBuffer(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprite sheet image: %w", err)
+	}
+	return sheet, nil
+}
+
+// DecodeSpriteSheet decodes a sprite sheet from Aseprite or TexturePacker JSON, using the
+// provided image rather than the one the JSON refers to.
+func DecodeSpriteSheet(r io.Reader, img *FrameBuffer) (*SpriteSheet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sprite sheet: %w", err)
+	}
+	sheet, _, err := parseSpriteSheet(data)
+	if err != nil {
+		return nil, err
+	}
+	sheet.Image = img
+	return sheet, nil
+}
+
+// sheetRect is a rectangle in a sprite sheet's JSON.
+type sheetRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// sheetFrame is a frame in a sprite sheet's JSON.
+type sheetFrame struct {
+	Filename         string    `json:"filename,omitempty"`
+	Frame            sheetRect `json:"frame"`
+	Rotated          bool      `json:"rotated"`
+	SpriteSourceSize sheetRect `json:"spriteSourceSize"`
+	SourceSize       sheetRect `json:"sourceSize"`
+	Duration         int       `json:"duration"` // milliseconds
+}
+
+// sheetFrames is the list of frames in a sprite sheet's JSON, which may be written as an
+// array or as an object keyed by name.
+type sheetFrames []sheetFrame
+
+// UnmarshalJSON implements json.Unmarshaler. The order of frames in an object is kept.
+func (s *sheetFrames) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, (*[]sheetFrame)(s))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var frame sheetFrame
+		if err := dec.Decode(&frame); err != nil {
+			return err
+		}
+		frame.Filename = key.(string)
+		*s = append(*s, frame)
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// sheetJSON is the layout of Aseprite and TexturePacker JSON. Aseprite writes clips as
+// frame tags, and TexturePacker as lists of frame names.
+type sheetJSON struct {
+	Frames     sheetFrames         `json:"frames"`
+	Animations map[string][]string `json:"animations"`
+	Meta       struct {
+		Image     string `json:"image"`
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"`
+		} `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// parseSpriteSheet parses a sprite sheet's JSON, returning the sheet without its image
+// and the path to the image.
+func parseSpriteSheet(data []byte) (*SpriteSheet, string, error) {
+	var js sheetJSON
+	if err := json.Unmarshal(data, &js); err != nil {
+		return nil, "", fmt.Errorf("failed to decode sprite sheet: %w", err)
+	}
+
+	sheet := &SpriteSheet{Clips: map[string]Clip{}}
+	names := map[string]int{}
+	for i, f := range js.Frames {
+		if f.Rotated {
+			return nil, "", fmt.Errorf("failed to decode sprite sheet: frame %q is rotated, which isn't supported", f.Filename)
+		}
+		frame := Frame{
+			Name:     f.Filename,
+			Source:   image.Rect(f.Frame.X, f.Frame.Y, f.Frame.X+f.Frame.W, f.Frame.Y+f.Frame.H),
+			Offset:   image.Pt(f.SpriteSourceSize.X, f.SpriteSourceSize.Y),
+			Size:     image.Pt(f.SourceSize.W, f.SourceSize.H),
+			Duration: time.Duration(f.Duration) * time.Millisecond,
+		}
+		if frame.Size == (image.Point{}) {
+			frame.Size = frame.Source.Size()
+		}
+		if frame.Duration <= 0 {
+			frame.Duration = defaultFrameDuration
+		}
+		sheet.Frames = append(sheet.Frames, frame)
+		names[f.Filename] = i
+	}
+
+	for _, tag := range js.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(sheet.Frames) || tag.From > tag.To {
+			return nil, "", fmt.Errorf("failed to decode sprite sheet: tag %q has invalid frames %d to %d", tag.Name, tag.From, tag.To)
+		}
+		clip := Clip{Mode: AnimationLoop}
+		for i := tag.From; i <= tag.To; i++ {
+			clip.Frames = append(clip.Frames, i)
+		}
+		switch tag.Direction {
+		case "", "forward":
+		case "reverse":
+			reverseFrames(clip.Frames)
+		case "pingpong":
+			clip.Mode = AnimationPingPong
+		case "pingpong_reverse":
+			clip.Mode = AnimationPingPong
+			reverseFrames(clip.Frames)
+		default:
+			return nil, "", fmt.Errorf("failed to decode sprite sheet: tag %q has unknown direction %q", tag.Name, tag.Direction)
+		}
+		sheet.Clips[tag.Name] = clip
+	}
+
+	for name, frameNames := range js.Animations {
+		clip := Clip{Mode: AnimationLoop}
+		for _, frameName := range frameNames {
+			i, ok := names[frameName]
+			if !ok {
+				return nil, "", fmt.Errorf("failed to decode sprite sheet: animation %q has unknown frame %q", name, frameName)
+			}
+			clip.Frames = append(clip.Frames, i)
+		}
+		sheet.Clips[name] = clip
+	}
+
+	return sheet, js.Meta.Image, nil
+}
+
+// reverseFrames reverses a list of frame indices in place.
+func reverseFrames(frames []int) {
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+}
+
+// AnimatedSprite is a sprite which plays clips from a sprite sheet. It is advanced by
+// calling Update with the time since the last update.
+type AnimatedSprite struct {
+	*Sprite
+	sheet     *SpriteSheet
+	clipName  string
+	clip      Clip
+	index     int // position within the clip's frames
+	direction int // 1 when playing forwards, -1 when playing backwards
+	elapsed   time.Duration
+	playing   bool
+	onFinish  func(clip string)
+}
+
+var _ Shape = (*AnimatedSprite)(nil)
+
+// NewAnimatedSprite constructs a new animated sprite, with the top left corner of its
+// frames at pos. It starts off looping through every frame of the sprite sheet.
+func NewAnimatedSprite(sheet *SpriteSheet, pos Vec) *AnimatedSprite {
+	all := Clip{Mode: AnimationLoop}
+	for i := range sheet.Frames {
+		all.Frames = append(all.Frames, i)
+	}
+
+	a := &AnimatedSprite{
+		Sprite: NewSprite(sheet.Image, pos),
+		sheet:  sheet,
+	}
+	a.play("", all)
+	return a
+}
+
+// Play plays a clip of the sprite sheet from its first frame. It returns false, and
+// leaves the animation as it was, if the sprite sheet has no clip with the name.
+func (a *AnimatedSprite) Play(name string) bool {
+	clip, ok := a.sheet.Clips[name]
+	if !ok {
+		return false
+	}
+	a.play(name, clip)
+	return true
+}
+
+// play plays a clip from its first frame.
+func (a *AnimatedSprite) play(name string, clip Clip) {
+	a.clipName, a.clip = name, clip
+	a.index, a.direction, a.elapsed = 0, 1, 0
+	a.playing = len(clip.Frames) > 0
+	a.showFrame()
+}
+
+// Clip returns the name of the clip being played, which is empty if it is every frame of
+// the sprite sheet.
+func (a *AnimatedSprite) Clip() string {
+	return a.clipName
+}
+
+// Frame returns the index in the sprite sheet of the frame being shown.
+func (a *AnimatedSprite) Frame() int {
+	if len(a.clip.Frames) == 0 {
+		return 0
+	}
+	return a.clip.Frames[a.index]
+}
+
+// Mode returns how the current clip is played.
+func (a *AnimatedSprite) Mode() AnimationMode {
+	return a.clip.Mode
+}
+
+// SetMode sets how the current clip is played, overriding the sprite sheet.
+func (a *AnimatedSprite) SetMode(mode AnimationMode) *AnimatedSprite {
+	a.clip.Mode = mode
+	return a
+}
+
+// Playing returns true if the animation is playing.
+func (a *AnimatedSprite) Playing() bool {
+	return a.playing
+}
+
+// Pause stops the animation on the current frame.
+func (a *AnimatedSprite) Pause() *AnimatedSprite {
+	a.playing = false
+	return a
+}
+
+// Resume continues playing the animation from the current frame.
+func (a *AnimatedSprite) Resume() *AnimatedSprite {
+	a.playing = len(a.clip.Frames) > 0
+	return a
+}
+
+// OnFinish sets a function which is called with the clip's name when it finishes. Looping
+// clips finish each time they reach their end, and ping-pong clips each time they return
+// to their start.
+func (a *AnimatedSprite) OnFinish(f func(clip string)) *AnimatedSprite {
+	a.onFinish = f
+	return a
+}
+
+// Update advances the animation by the time since the last update.
+func (a *AnimatedSprite) Update(dt time.Duration) {
+	if !a.playing {
+		return
+	}
+
+	a.elapsed += dt
+	for a.playing {
+		d := a.sheet.Frames[a.Frame()].Duration
+		if d <= 0 {
+			d = defaultFrameDuration
+		}
+		if a.elapsed < d {
+			break
+		}
+		a.elapsed -= d
+		a.advance()
+	}
+	a.showFrame()
+}
+
+// advance moves on to the next frame of the clip.
+func (a *AnimatedSprite) advance() {
+	last := len(a.clip.Frames) - 1
+
+	switch a.clip.Mode {
+	case AnimationPingPong:
+		if last == 0 {
+			a.finish()
+			return
+		}
+		a.index += a.direction
+		if a.index == last {
+			a.direction = -1
+		} else if a.index == 0 {
+			a.direction = 1
+			a.finish()
+		}
+	case AnimationOneShot:
+		if a.index == last {
+			a.playing = false
+			a.elapsed = 0
+			a.finish()
+			return
+		}
+		a.index++
+	default:
+		a.index++
+		if a.index > last {
+			a.index = 0
+			a.finish()
+		}
+	}
+}
+
+// finish calls the finish callback, if there is one.
+func (a *AnimatedSprite) finish() {
+	if a.onFinish != nil {
+		a.onFinish(a.clipName)
+	}
+}
+
+// showFrame sets the sprite to draw the current frame.
+func (a *AnimatedSprite) showFrame() {
+	if len(a.clip.Frames) == 0 {
+		return
+	}
+	frame := a.sheet.Frames[a.Frame()]
+	a.Sprite.setFrame(frame.Source, frame.Offset, frame.Size)
+}
+
+// String returns the name of the shape.
+func (a *AnimatedSprite) String() string {
+	return "animated sprite"
+}
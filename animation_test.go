@@ -0,0 +1,171 @@
+package gogl
+
+import (
+	"image"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadSpriteSheet(t *testing.T) {
+	sheet, err := LoadSpriteSheet("testdata/sprites.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sheet.Image.width != 16 || sheet.Image.height != 8 {
+		t.Errorf("Expected a 16x8 image, got %dx%d", sheet.Image.width, sheet.Image.height)
+	}
+	wantFrames := []Frame{
+		{Name: "arrow 0.aseprite", Source: image.Rect(0, 0, 8, 8), Size: image.Pt(8, 8), Duration: 100 * time.Millisecond},
+		{Name: "arrow 1.aseprite", Source: image.Rect(8, 0, 16, 8), Size: image.Pt(8, 8), Duration: 200 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(sheet.Frames, wantFrames) {
+		t.Errorf("Expected frames %v, got %v", wantFrames, sheet.Frames)
+	}
+	wantClips := map[string]Clip{
+		"turn": {Frames: []int{0, 1}, Mode: AnimationPingPong},
+		"back": {Frames: []int{1, 0}, Mode: AnimationLoop},
+	}
+	if !reflect.DeepEqual(sheet.Clips, wantClips) {
+		t.Errorf("Expected clips %v, got %v", wantClips, sheet.Clips)
+	}
+}
+
+func TestLoadSpriteSheetFS(t *testing.T) {
+	data, err := os.ReadFile("testdata/sprites.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// TexturePacker writes frames as an array, and may trim them
+	const js = `{
+		"frames": [
+			{"filename": "walk_1.png", "frame": {"x": 0, "y": 0, "w": 8, "h": 8}, "rotated": false,
+			 "spriteSourceSize": {"x": 2, "y": 1, "w": 8, "h": 8}, "sourceSize": {"w": 12, "h": 10}},
+			{"filename": "walk_2.png", "frame": {"x": 8, "y": 0, "w": 8, "h": 8}, "rotated": false,
+			 "spriteSourceSize": {"x": 2, "y": 1, "w": 8, "h": 8}, "sourceSize": {"w": 12, "h": 10}}
+		],
+		"animations": {"walk": ["walk_2.png", "walk_1.png"]},
+		"meta": {"image": "sheet.png"}
+	}`
+	fsys := fstest.MapFS{
+		"assets/sheet.json": {Data: []byte(js)},
+		"assets/sheet.png":  {Data: data},
+	}
+	sheet, err := LoadSpriteSheetFS(fsys, "assets/sheet.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Frame{
+		Name:     "walk_2.png",
+		Source:   image.Rect(8, 0, 16, 8),
+		Offset:   image.Pt(2, 1),
+		Size:     image.Pt(12, 10),
+		Duration: defaultFrameDuration,
+	}
+	if sheet.Frames[1] != want {
+		t.Errorf("Expected frame %v, got %v", want, sheet.Frames[1])
+	}
+	if got := sheet.Clips["walk"].Frames; !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Errorf("Expected walk frames [1 0], got %v", got)
+	}
+
+	// Trimmed frames are drawn in their place within the whole frame
+	s := NewAnimatedSprite(sheet, Vec{0, 0})
+	if !s.Play("walk") {
+		t.Fatal("Expected walk clip to play")
+	}
+	if s.Width() != 12 || s.Height() != 10 {
+		t.Errorf("Expected size 12x10, got %vx%v", s.Width(), s.Height())
+	}
+	f := NewFrameBuffer(12, 10)
+	s.Draw(f)
+	if got, want := f.GetPixel(2+3, 1), NewPixel(sheet.Image.At(8+3, 0)); got != want {
+		t.Errorf("Expected trimmed frame to be offset, got %v want %v", got, want)
+	}
+}
+
+func TestParseSpriteSheetErrors(t *testing.T) {
+	for _, tc := range []struct {
+		js   string
+		want string
+	}{
+		{`{"frames": [`, "failed to decode sprite sheet"},
+		{`{"frames": [{"filename": "a", "rotated": true}]}`, "rotated"},
+		{`{"frames": [], "meta": {"frameTags": [{"name": "a", "from": 0, "to": 1}]}}`, "invalid frames"},
+		{`{"frames": [], "animations": {"a": ["missing"]}}`, "unknown frame"},
+	} {
+		_, err := DecodeSpriteSheet(strings.NewReader(tc.js), NewFrameBuffer(1, 1))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Parsing %s: expected error containing %q, got %v", tc.js, tc.want, err)
+		}
+	}
+}
+
+func TestAnimatedSpriteModes(t *testing.T) {
+	sheet := &SpriteSheet{Image: NewFrameBuffer(30, 10), Clips: map[string]Clip{}}
+	for i := 0; i < 3; i++ {
+		sheet.Frames = append(sheet.Frames, Frame{
+			Source:   image.Rect(i*10, 0, i*10+10, 10),
+			Size:     image.Pt(10, 10),
+			Duration: 10 * time.Millisecond,
+		})
+	}
+
+	for _, tc := range []struct {
+		mode     AnimationMode
+		want     []int
+		finishes int
+	}{
+		{AnimationLoop, []int{1, 2, 0, 1, 2, 0, 1}, 2},
+		{AnimationPingPong, []int{1, 2, 1, 0, 1, 2, 1}, 1},
+		{AnimationOneShot, []int{1, 2, 2, 2, 2, 2, 2}, 1},
+	} {
+		sheet.Clips["clip"] = Clip{Frames: []int{0, 1, 2}, Mode: tc.mode}
+		finishes := 0
+		s := NewAnimatedSprite(sheet, Vec{})
+		s.Play("clip")
+		s.OnFinish(func(clip string) {
+			if clip != "clip" {
+				t.Errorf("Expected clip name in callback, got %q", clip)
+			}
+			finishes++
+		})
+
+		var got []int
+		for range tc.want {
+			s.Update(10 * time.Millisecond)
+			got = append(got, s.Frame())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: expected frames %v, got %v", tc.mode, tc.want, got)
+		}
+		if finishes != tc.finishes {
+			t.Errorf("%v: expected %d finishes, got %d", tc.mode, tc.finishes, finishes)
+		}
+		if s.Source() != sheet.Frames[s.Frame()].Source {
+			t.Errorf("%v: expected sprite to show frame %d", tc.mode, s.Frame())
+		}
+	}
+
+	// Unknown clips are ignored
+	s := NewAnimatedSprite(sheet, Vec{})
+	if s.Play("missing") || s.Clip() != "" || !s.Playing() {
+		t.Errorf("Expected unknown clip to leave the animation playing, got clip %q", s.Clip())
+	}
+
+	// Long updates can skip frames
+	s.Update(25 * time.Millisecond)
+	if s.Frame() != 2 {
+		t.Errorf("Expected to skip to frame 2, got %d", s.Frame())
+	}
+	s.Pause().Update(time.Second)
+	if s.Frame() != 2 || s.Playing() {
+		t.Errorf("Expected paused sprite to stay on frame 2, got %d", s.Frame())
+	}
+}
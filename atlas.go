@@ -0,0 +1,351 @@
+package gogl
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Atlas is a single image containing many smaller images, each of which is found by name.
+type Atlas struct {
+	Image   *FrameBuffer
+	Regions map[string]image.Rectangle
+}
+
+// Region returns the region of the atlas's image which contains the named image.
+func (a *Atlas) Region(name string) (image.Rectangle, bool) {
+	r, ok := a.Regions[name]
+	return r, ok
+}
+
+// Sprite returns a new sprite which draws the named image. It panics if the atlas has
+// no image with the name.
+func (a *Atlas) Sprite(name string) *Sprite {
+	r, ok := a.Regions[name]
+	if !ok {
+		panic(fmt.Sprintf("atlas has no image named %q", name))
+	}
+	return NewSprite(a.Image, Vec{}).SetSource(r)
+}
+
+// atlasJSON is the layout of an atlas's JSON index, which is the same as TexturePacker's
+// so that it can also be loaded as a sprite sheet.
+type atlasJSON struct {
+	Frames map[string]sheetFrame `json:"frames"`
+	Meta   struct {
+		App   string    `json:"app"`
+		Image string    `json:"image"`
+		Size  sheetRect `json:"size"`
+	} `json:"meta"`
+}
+
+// Save writes the atlas's image to an image file, and its regions to a JSON index. The
+// image format is chosen from the file extension, as for FrameBuffer.Save.
+func (a *Atlas) Save(imagePath, indexPath string) error {
+	if err := a.Image.Save(imagePath); err != nil {
+		return fmt.Errorf("failed to save atlas image: %w", err)
+	}
+
+	// Refer to the image relative to the index, so that they can be moved together
+	rel, err := filepath.Rel(filepath.Dir(indexPath), imagePath)
+	if err != nil {
+		rel = imagePath
+	}
+
+	var js atlasJSON
+	js.Frames = make(map[string]sheetFrame, len(a.Regions))
+	for name, r := range a.Regions {
+		rect := sheetRect{X: r.Min.X, Y: r.Min.Y, W: r.Dx(), H: r.Dy()}
+		js.Frames[name] = sheetFrame{
+			Frame:            rect,
+			SpriteSourceSize: sheetRect{W: rect.W, H: rect.H},
+			SourceSize:       sheetRect{W: rect.W, H: rect.H},
+		}
+	}
+	js.Meta.App = "gogl"
+	js.Meta.Image = filepath.ToSlash(rel)
+	js.Meta.Size = sheetRect{W: a.Image.width, H: a.Image.height}
+
+	data, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode atlas index: %w", err)
+	}
+	return os.WriteFile(indexPath, data, 0o644)
+}
+
+// LoadAtlas loads an atlas from a JSON index, along with the image it refers to. Any
+// TexturePacker or Aseprite JSON can be loaded, with each frame becoming a region.
+func LoadAtlas(indexPath string) (*Atlas, error) {
+	sheet, err := LoadSpriteSheet(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Atlas{Image: sheet.Image, Regions: make(map[string]image.Rectangle, len(sheet.Frames))}
+	for _, f := range sheet.Frames {
+		a.Regions[f.Name] = f.Source
+	}
+	return a, nil
+}
+
+// AtlasBuilder packs images into an atlas.
+type AtlasBuilder struct {
+	entries  []atlasEntry
+	names    map[string]bool
+	padding  int
+	extrude  int
+	maxWidth int
+}
+
+// atlasEntry is an image waiting to be packed into an atlas.
+type atlasEntry struct {
+	name string
+	img  *FrameBuffer
+}
+
+// NewAtlasBuilder constructs a new atlas builder. By default, images are separated by a
+// pixel of padding, are not extruded, and the atlas is at most 2048 pixels wide.
+func NewAtlasBuilder() *AtlasBuilder {
+	return &AtlasBuilder{
+		names:    map[string]bool{},
+		padding:  1,
+		maxWidth: 2048,
+	}
+}
+
+// SetPadding sets the number of transparent pixels left between images.
+func (b *AtlasBuilder) SetPadding(px int) *AtlasBuilder {
+	b.padding = max(px, 0)
+	return b
+}
+
+// SetExtrude sets the number of times the edge pixels of each image are repeated around
+// it. Extrusion stops neighbouring images bleeding in when images are drawn with a
+// filter.
+func (b *AtlasBuilder) SetExtrude(px int) *AtlasBuilder {
+	b.extrude = max(px, 0)
+	return b
+}
+
+// SetMaxWidth sets the maximum width of the atlas in pixels.
+func (b *AtlasBuilder) SetMaxWidth(px int) *AtlasBuilder {
+	b.maxWidth = max(px, 1)
+	return b
+}
+
+// Add adds an image to be packed into the atlas. It panics if an image has already been
+// added with the name.
+func (b *AtlasBuilder) Add(name string, img *FrameBuffer) *AtlasBuilder {
+	if b.names[name] {
+		panic(fmt.Sprintf("atlas already has an image named %q", name))
+	}
+	b.names[name] = true
+	b.entries = append(b.entries, atlasEntry{name: name, img: img})
+	return b
+}
+
+// AddGlyphs adds the glyph of each character in chars, drawn in white in a font with a
+// size in pixels. Each glyph is named with the prefix followed by its character.
+// Characters without any pixels, such as spaces, are left out, as are characters which
+// have already been added. If the font is missing any of the glyphs, an error is
+// returned and none of them are added.
+func (b *AtlasBuilder) AddGlyphs(prefix, fontPath string, size float64, chars string) error {
+	f, err := loadFont(fontPath)
+	if err != nil {
+		return fmt.Errorf("failed to load font: %w", err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create font face: %w", err)
+	}
+	defer face.Close()
+
+	// Draw every glyph before adding any, so that the builder is left as it was if one is
+	// missing
+	var glyphs []atlasEntry
+	added := map[string]bool{}
+	for _, r := range chars {
+		name := prefix + string(r)
+		if b.names[name] || added[name] {
+			continue
+		}
+		dr, mask, maskp, _, ok := face.Glyph(fixed.Point26_6{}, r)
+		if !ok {
+			return fmt.Errorf("font has no glyph for %q", r)
+		}
+		if dr.Empty() {
+			continue
+		}
+		glyph := NewFrameBuffer(dr.Dx(), dr.Dy())
+		for y := 0; y < dr.Dy(); y++ {
+			for x := 0; x < dr.Dx(); x++ {
+				_, _, _, a := mask.At(maskp.X+x, maskp.Y+y).RGBA()
+				glyph.setPixel(x, y, pack(uint8(a>>8), 0xff, 0xff, 0xff))
+			}
+		}
+		added[name] = true
+		glyphs = append(glyphs, atlasEntry{name: name, img: glyph})
+	}
+
+	for _, g := range glyphs {
+		b.Add(g.name, g.img)
+	}
+	return nil
+}
+
+// Build packs the images into a new atlas, using a skyline packer. Images are placed as
+// low as possible, tallest first. The atlas is as narrow as possible while staying
+// roughly square, and only as tall as it needs to be.
+func (b *AtlasBuilder) Build() (*Atlas, error) {
+	// Each image takes up a cell, which includes its extrusion and padding
+	border := 2*b.extrude + b.padding
+	cellSize := func(e atlasEntry) image.Point {
+		return image.Pt(e.img.width+border, e.img.height+border)
+	}
+
+	order := make([]int, len(b.entries))
+	area, widest := 0, 1
+	for i, e := range b.entries {
+		order[i] = i
+		size := cellSize(e)
+		area += size.X * size.Y
+		widest = max(widest, size.X)
+	}
+	if widest > b.maxWidth {
+		return nil, fmt.Errorf("failed to build atlas: image is %d pixels wide, but the atlas can be at most %d",
+			widest, b.maxWidth)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, c := cellSize(b.entries[order[i]]), cellSize(b.entries[order[j]])
+		if a.Y != c.Y {
+			return a.Y > c.Y
+		}
+		return a.X > c.X
+	})
+
+	width := 1
+	for width < widest || width*width < area {
+		width *= 2
+	}
+	width = min(width, b.maxWidth)
+
+	sky := skyline{{width: width}}
+	positions := make([]image.Point, len(b.entries))
+	height := 0
+	for _, i := range order {
+		size := cellSize(b.entries[i])
+		pos := sky.place(size)
+		positions[i] = pos
+		height = max(height, pos.Y+size.Y)
+	}
+
+	// Frame buffers can't be empty, so an atlas without any pixels to pack is given a
+	// single transparent pixel
+	height = max(height, 1)
+
+	atlas := &Atlas{
+		Image:   NewFrameBuffer(width, height),
+		Regions: make(map[string]image.Rectangle, len(b.entries)),
+	}
+	for i, e := range b.entries {
+		topLeft := positions[i].Add(image.Pt(b.extrude, b.extrude))
+		atlas.Regions[e.name] = image.Rectangle{Min: topLeft, Max: topLeft.Add(image.Pt(e.img.width, e.img.height))}
+		b.copyExtruded(atlas.Image, e.img, topLeft)
+	}
+	return atlas, nil
+}
+
+// copyExtruded copies an image into the atlas with its top left corner at a point,
+// repeating its edge pixels outwards.
+func (b *AtlasBuilder) copyExtruded(dst, src *FrameBuffer, at image.Point) {
+	if src.width == 0 || src.height == 0 {
+		return
+	}
+	convert := dst.convertFrom(src)
+	for y := -b.extrude; y < src.height+b.extrude; y++ {
+		for x := -b.extrude; x < src.width+b.extrude; x++ {
+			p := src.getPixel(Clamp(x, 0, src.width-1), Clamp(y, 0, src.height-1))
+			if convert != nil {
+				p = convert(p)
+			}
+			dst.setPixel(at.X+x, at.Y+y, p)
+		}
+	}
+}
+
+// skyline is the top edge of the images packed so far, as segments from left to right.
+type skyline []skylineSegment
+
+// skylineSegment is a horizontal part of a skyline.
+type skylineSegment struct {
+	x, y, width int
+}
+
+// place finds the lowest position where a cell of the given size fits on top of the
+// skyline, preferring positions further left, and raises the skyline over it.
+func (s *skyline) place(size image.Point) image.Point {
+	best, bestPos := -1, image.Pt(0, math.MaxInt)
+	for i, seg := range *s {
+		if y, ok := s.fit(i, size.X); ok && y < bestPos.Y {
+			best, bestPos = i, image.Pt(seg.x, y)
+		}
+	}
+	if best < 0 {
+		// Only happens if the cell is wider than the atlas
+		panic("atlas cell is wider than the atlas")
+	}
+
+	// Replace the segments under the cell with a new segment on top of it
+	segments := *s
+	end := bestPos.X + size.X
+	var next skyline
+	next = append(next, segments[:best]...)
+	next = append(next, skylineSegment{x: bestPos.X, y: bestPos.Y + size.Y, width: size.X})
+	for _, seg := range segments[best:] {
+		if segEnd := seg.x + seg.width; segEnd > end {
+			if seg.x < end {
+				seg.width = segEnd - end
+				seg.x = end
+			}
+			next = append(next, seg)
+		}
+	}
+
+	// Merge neighbouring segments at the same height
+	merged := next[:1]
+	for _, seg := range next[1:] {
+		if last := &merged[len(merged)-1]; last.y == seg.y {
+			last.width += seg.width
+		} else {
+			merged = append(merged, seg)
+		}
+	}
+	*s = merged
+	return bestPos
+}
+
+// fit returns the height a cell of the given width would sit at if its left edge was at
+// the start of segment i, or false if it would overhang the right of the skyline.
+func (s skyline) fit(i, width int) (int, bool) {
+	y, remaining := 0, width
+	for ; remaining > 0; i++ {
+		if i == len(s) {
+			return 0, false
+		}
+		y = max(y, s[i].y)
+		remaining -= s[i].width
+	}
+	return y, true
+}
@@ -0,0 +1,170 @@
+package gogl
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// solidFrameBuffer returns a frame buffer filled with a colour.
+func solidFrameBuffer(width, height int, c color.Color) *FrameBuffer {
+	f := NewFrameBuffer(width, height)
+	f.Fill(c)
+	return f
+}
+
+func TestSkyline(t *testing.T) {
+	sky := skyline{{width: 10}}
+	for _, tc := range []struct {
+		size image.Point
+		want image.Point
+	}{
+		{image.Pt(4, 5), image.Pt(0, 0)},
+		{image.Pt(4, 3), image.Pt(4, 0)},
+		{image.Pt(3, 2), image.Pt(4, 3)}, // on the lower of the two segments that fit
+		{image.Pt(2, 2), image.Pt(8, 0)},
+		{image.Pt(10, 1), image.Pt(0, 5)},
+	} {
+		if got := sky.place(tc.size); got != tc.want {
+			t.Errorf("Expected %v to be placed at %v, got %v", tc.size, tc.want, got)
+		}
+	}
+	if want := (skyline{{0, 6, 10}}); !reflect.DeepEqual(sky, want) {
+		t.Errorf("Expected skyline %v, got %v", want, sky)
+	}
+}
+
+func TestAtlasBuild(t *testing.T) {
+	colours := []color.Color{Red, Lime, Blue, Yellow, Cyan, Magenta, White, Orange}
+	b := NewAtlasBuilder().SetPadding(1).SetExtrude(2)
+	for i, c := range colours {
+		b.Add(string(rune('a'+i)), solidFrameBuffer(3+i, 10-i, c))
+	}
+	atlas, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cells []image.Rectangle
+	for i, c := range colours {
+		r, ok := atlas.Region(string(rune('a' + i)))
+		if !ok {
+			t.Fatalf("Expected region for image %d", i)
+		}
+		if r.Dx() != 3+i || r.Dy() != 10-i {
+			t.Errorf("Expected region %d to be %dx%d, got %v", i, 3+i, 10-i, r)
+		}
+
+		// Each image and its extrusion is filled with its colour
+		cell := r.Inset(-2)
+		if !cell.In(atlas.Image.Bounds()) {
+			t.Fatalf("Expected extruded region %v to be within the atlas", cell)
+		}
+		for _, pt := range []image.Point{cell.Min, r.Min, cell.Max.Sub(image.Pt(1, 1))} {
+			if got := atlas.Image.GetPixel(pt.X, pt.Y); got != NewPixel(c) {
+				t.Errorf("Expected pixel %v of image %d to be %v, got %v", pt, i, NewPixel(c), got)
+			}
+		}
+
+		// Cells are separated by padding
+		for _, other := range cells {
+			if other.Overlaps(cell.Inset(-1)) {
+				t.Errorf("Expected %v and %v to be padded apart", other, cell)
+			}
+		}
+		cells = append(cells, cell)
+	}
+}
+
+func TestAtlasBuildEmpty(t *testing.T) {
+	for name, b := range map[string]*AtlasBuilder{
+		"no images":    NewAtlasBuilder(),
+		"empty images": NewAtlasBuilder().SetPadding(0).Add("a", &FrameBuffer{}).Add("b", &FrameBuffer{}),
+	} {
+		atlas, err := b.Build()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if atlas.Image.Width() != 1 || atlas.Image.Height() != 1 {
+			t.Errorf("%s: expected a 1x1 image, got %dx%d", name, atlas.Image.Width(), atlas.Image.Height())
+		}
+		if len(atlas.Regions) != len(b.entries) {
+			t.Errorf("%s: expected %d regions, got %d", name, len(b.entries), len(atlas.Regions))
+		}
+	}
+}
+
+func TestAtlasBuildTooWide(t *testing.T) {
+	_, err := NewAtlasBuilder().SetMaxWidth(16).Add("wide", NewFrameBuffer(20, 1)).Build()
+	if err == nil {
+		t.Error("Expected an error for an image wider than the atlas")
+	}
+}
+
+func TestAtlasAddGlyphs(t *testing.T) {
+	// Repeated characters are only added once
+	b := NewAtlasBuilder()
+	if err := b.AddGlyphs("g/", "fonts/luxisr.ttf", 16, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddGlyphs("g/", "fonts/luxisr.ttf", 16, "world"); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.entries) != 7 {
+		t.Errorf("Expected 7 glyphs, got %d", len(b.entries))
+	}
+
+	// No glyphs are added if any are missing
+	if err := b.AddGlyphs("h/", "fonts/luxisr.ttf", 16, "ab世"); err == nil {
+		t.Error("Expected an error for a missing glyph")
+	}
+	if len(b.entries) != 7 {
+		t.Errorf("Expected no glyphs to be added, got %d", len(b.entries)-7)
+	}
+}
+
+func TestAtlasSaveLoad(t *testing.T) {
+	b := NewAtlasBuilder().
+		Add("red", solidFrameBuffer(4, 4, Red)).
+		Add("blue", solidFrameBuffer(6, 2, Blue))
+	if err := b.AddGlyphs("glyph/", "fonts/luxisr.ttf", 16, "ab "); err != nil {
+		t.Fatal(err)
+	}
+	atlas, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := atlas.Regions["glyph/a"]; r.Empty() {
+		t.Errorf("Expected glyph to have a region, got %v", r)
+	}
+	if _, ok := atlas.Regions["glyph/ "]; ok {
+		t.Error("Expected empty glyph to be left out")
+	}
+
+	dir := t.TempDir()
+	if err := atlas.Save(filepath.Join(dir, "images", "atlas.png"), filepath.Join(dir, "atlas.json")); err == nil {
+		t.Error("Expected an error saving into a missing directory")
+	}
+	if err := atlas.Save(filepath.Join(dir, "atlas.png"), filepath.Join(dir, "atlas.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAtlas(filepath.Join(dir, "atlas.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Regions, atlas.Regions) {
+		t.Errorf("Expected regions %v, got %v", atlas.Regions, loaded.Regions)
+	}
+	if !reflect.DeepEqual(loaded.Image.fb, atlas.Image.fb) {
+		t.Error("Expected loaded image to match the saved image")
+	}
+
+	s := loaded.Sprite("blue")
+	if s.Width() != 6 || s.Height() != 2 {
+		t.Errorf("Expected sprite size 6x2, got %vx%v", s.Width(), s.Height())
+	}
+}
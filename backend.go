@@ -0,0 +1,63 @@
+package gogl
+
+import "image"
+
+// Backend is the platform layer behind a window. It owns the OS resources, presents
+// rendered frames and supplies input events.
+//
+// The default backend uses SDL. A HeadlessBackend can be used instead to run windows
+// without a display, such as in tests or on a server.
+type Backend interface {
+	// Init prepares the backend for use with the given window configuration.
+	Init(cfg WindowCfg) error
+	// Destroy releases any resources held by the backend.
+	Destroy()
+	// PollEvent returns the next pending event. The boolean is false if there are
+	// no events left to process.
+	PollEvent() (Event, bool)
+	// Present displays the contents of the frame buffer. Only the dirty regions have
+	// changed since the previous call, so the rest of the frame can be reused.
+	Present(buf *FrameBuffer, dirty []image.Rectangle) error
+	// Mouse returns the location of the mouse cursor, relative to the origin of the
+	// window, and the state of the mouse buttons.
+	Mouse() (Vec, MouseState)
+	// Size returns the dimensions of the window, in pixels.
+	Size() (width, height int)
+	// SetTitle sets the title of the window.
+	SetTitle(title string) error
+}
+
+// Event is an input event produced by a backend.
+type Event interface {
+	isEvent()
+}
+
+// QuitEvent is produced when the user requests the window to close.
+type QuitEvent struct{}
+
+// KeyEvent is produced when a key is pressed or released.
+type KeyEvent struct {
+	// Key is the virtual key code of the key.
+	Key Keycode
+	// Down is true if the key was pressed, and false if it was released.
+	Down bool
+	// Repeat is true if the event was generated by the key being held down.
+	Repeat bool
+}
+
+// TextInputEvent is produced when the user enters text.
+type TextInputEvent struct {
+	// Text is the text that was entered.
+	Text string
+}
+
+// MouseWheelEvent is produced when the user scrolls the mouse wheel.
+type MouseWheelEvent struct {
+	// Movement is the scroll vector. See MouseScrollCallback.
+	Movement Vec
+}
+
+func (QuitEvent) isEvent()       {}
+func (KeyEvent) isEvent()        {}
+func (TextInputEvent) isEvent()  {}
+func (MouseWheelEvent) isEvent() {}
@@ -0,0 +1,232 @@
+package gogl
+
+import "math"
+
+// The Porter-Duff compositing operators. Each operator combines the source and
+// destination in proportions determined by their alpha values. AlphaBlend provides the
+// source-over operator.
+
+// ClearBlend clears the destination, regardless of the source.
+func ClearBlend(_, _ Pixel) Pixel {
+	return 0
+}
+
+// SrcBlend replaces the destination with the source.
+func SrcBlend(src, _ Pixel) Pixel {
+	return src
+}
+
+// DstBlend keeps the destination, ignoring the source.
+func DstBlend(_, dst Pixel) Pixel {
+	return dst
+}
+
+// DstOverBlend draws the destination over the source.
+func DstOverBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, math.MaxUint8-uint32(dst.A()), math.MaxUint8)
+}
+
+// SrcInBlend keeps the part of the source which overlaps the destination.
+func SrcInBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, uint32(dst.A()), 0)
+}
+
+// DstInBlend keeps the part of the destination which overlaps the source.
+func DstInBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, 0, uint32(src.A()))
+}
+
+// SrcOutBlend keeps the part of the source which doesn't overlap the destination.
+func SrcOutBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, math.MaxUint8-uint32(dst.A()), 0)
+}
+
+// DstOutBlend keeps the part of the destination which doesn't overlap the source.
+func DstOutBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, 0, math.MaxUint8-uint32(src.A()))
+}
+
+// SrcAtopBlend draws the part of the source which overlaps the destination over the
+// destination.
+func SrcAtopBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, uint32(dst.A()), math.MaxUint8-uint32(src.A()))
+}
+
+// DstAtopBlend draws the part of the destination which overlaps the source over the
+// source.
+func DstAtopBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, math.MaxUint8-uint32(dst.A()), uint32(src.A()))
+}
+
+// XorBlend keeps the parts of the source and destination which don't overlap.
+func XorBlend(src, dst Pixel) Pixel {
+	return porterDuff(src, dst, math.MaxUint8-uint32(dst.A()), math.MaxUint8-uint32(src.A()))
+}
+
+// porterDuff composites a source and destination pixel, where fa and fb are the
+// fractions of the source and destination to keep, scaled from 0 to 255.
+func porterDuff(src, dst Pixel, fa, fb uint32) Pixel {
+	srcW := uint32(src.A()) * fa
+	dstW := uint32(dst.A()) * fb
+
+	// Handle fully transparent case
+	sum := srcW + dstW
+	if sum == 0 {
+		return 0
+	}
+
+	a := Clamp((sum+math.MaxUint8/2)/math.MaxUint8, 0, math.MaxUint8)
+	r := (uint32(src.R())*srcW + uint32(dst.R())*dstW) / sum
+	g := (uint32(src.G())*srcW + uint32(dst.G())*dstW) / sum
+	b := (uint32(src.B())*srcW + uint32(dst.B())*dstW) / sum
+
+	return pack(uint8(a), uint8(b), uint8(g), uint8(r))
+}
+
+// The separable blend modes. Each mode mixes the colour channels of the source and
+// destination independently where they overlap, then composites the result over the
+// destination like AlphaBlend. See https://www.w3.org/TR/compositing-1/#blending.
+
+// MultiplyBlend multiplies the source and destination colours, which always darkens.
+func MultiplyBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, multiply)
+}
+
+// ScreenBlend multiplies the complements of the source and destination colours, which
+// always lightens.
+func ScreenBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, screen)
+}
+
+// OverlayBlend multiplies or screens the colours depending on the destination colour,
+// which increases contrast.
+func OverlayBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		return hardLight(d, s)
+	})
+}
+
+// DarkenBlend keeps the darker of the source and destination colours.
+func DarkenBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, math.Min)
+}
+
+// LightenBlend keeps the lighter of the source and destination colours.
+func LightenBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, math.Max)
+}
+
+// ColourDodgeBlend brightens the destination colour to reflect the source colour.
+func ColourDodgeBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		switch {
+		case d == 0:
+			return 0
+		case s == 1:
+			return 1
+		default:
+			return math.Min(1, d/(1-s))
+		}
+	})
+}
+
+// ColourBurnBlend darkens the destination colour to reflect the source colour.
+func ColourBurnBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		switch {
+		case d == 1:
+			return 1
+		case s == 0:
+			return 0
+		default:
+			return 1 - math.Min(1, (1-d)/s)
+		}
+	})
+}
+
+// HardLightBlend multiplies or screens the colours depending on the source colour,
+// similar to shining a harsh spotlight on the destination.
+func HardLightBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, hardLight)
+}
+
+// SoftLightBlend darkens or lightens the colours depending on the source colour,
+// similar to shining a diffused spotlight on the destination.
+func SoftLightBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		if s <= 0.5 {
+			return d - (1-2*s)*d*(1-d)
+		}
+		var dd float64
+		if d <= 0.25 {
+			dd = ((16*d-12)*d + 4) * d
+		} else {
+			dd = math.Sqrt(d)
+		}
+		return d + (2*s-1)*(dd-d)
+	})
+}
+
+// DifferenceBlend subtracts the darker of the source and destination colours from the
+// lighter.
+func DifferenceBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		return math.Abs(d - s)
+	})
+}
+
+// ExclusionBlend is similar to DifferenceBlend, but with lower contrast.
+func ExclusionBlend(src, dst Pixel) Pixel {
+	return separableBlend(src, dst, func(s, d float64) float64 {
+		return s + d - 2*s*d
+	})
+}
+
+// multiply is the multiply blend function for normalised colour channels.
+func multiply(s, d float64) float64 {
+	return s * d
+}
+
+// screen is the screen blend function for normalised colour channels.
+func screen(s, d float64) float64 {
+	return s + d - s*d
+}
+
+// hardLight is the hard light blend function for normalised colour channels.
+func hardLight(s, d float64) float64 {
+	if s <= 0.5 {
+		return multiply(2*s, d)
+	}
+	return screen(2*s-1, d)
+}
+
+// separableBlend mixes each colour channel of the source and destination using the mix
+// function, then composites the result over the destination. The mix function takes
+// normalised source and destination channel values, from 0 to 1.
+func separableBlend(src, dst Pixel, mix func(s, d float64) float64) Pixel {
+	srcA := float64(src.A()) / math.MaxUint8
+	dstA := float64(dst.A()) / math.MaxUint8
+
+	// Handle fully transparent case
+	a := srcA + dstA*(1-srcA)
+	if a == 0 {
+		return 0
+	}
+
+	channel := func(srcC, dstC uint8) uint8 {
+		s := float64(srcC) / math.MaxUint8
+		d := float64(dstC) / math.MaxUint8
+
+		// The mixed colour only applies where the destination is present
+		mixed := (1-dstA)*s + dstA*Clamp(mix(s, d), 0, 1)
+		c := (srcA*mixed + (1-srcA)*dstA*d) / a
+		return uint8(math.Round(c * math.MaxUint8))
+	}
+
+	return pack(
+		uint8(math.Round(a*math.MaxUint8)),
+		channel(src.B(), dst.B()),
+		channel(src.G(), dst.G()),
+		channel(src.R(), dst.R()),
+	)
+}
@@ -0,0 +1,276 @@
+package gogl
+
+import (
+	"image"
+	"math"
+	"reflect"
+)
+
+// Filter is a resampling filter used when drawing a frame buffer at a different scale.
+type Filter int
+
+const (
+	FilterNearest  Filter = iota // use the nearest source pixel
+	FilterBilinear               // interpolate between the nearest 2x2 source pixels
+	FilterBicubic                // interpolate between the nearest 4x4 source pixels
+)
+
+// String returns the name of the filter.
+func (f Filter) String() string {
+	switch f {
+	case FilterNearest:
+		return "nearest"
+	case FilterBilinear:
+		return "bilinear"
+	case FilterBicubic:
+		return "bicubic"
+	default:
+		return "invalid"
+	}
+}
+
+// Blit draws a rectangular region of the source frame buffer onto this frame buffer,
+// with the top-left of the region placed at dstPos. Pixels are combined using the blend
+// function, or AlphaBlend if nil. The source and destination must not share memory,
+// unless they are the same frame buffer. Pixels are converted if the frame buffers use
+// different alpha formats.
+//
+// Rows are copied directly when using SrcBlend, or when alpha blending rows of fully
+// opaque source pixels, as the result would be identical.
+func (f *FrameBuffer) Blit(src *FrameBuffer, srcRect image.Rectangle, dstPos image.Point, blend BlendFunc) {
+	if blend == nil {
+		blend = AlphaBlend
+	}
+	convert := f.convertFrom(src)
+
+	// Restrict the region to pixels that exist in the source and can be drawn in the
+	// destination. The offset is found first, so that pixels stay in place when the
+	// region starts outside the source.
+	offset := dstPos.Sub(srcRect.Min)
+	srcRect = srcRect.Intersect(src.Bounds())
+	dstRect := srcRect.Add(offset).Intersect(f.clip)
+	if dstRect.Empty() {
+		return
+	}
+	srcRect = dstRect.Sub(offset)
+	f.MarkDirty(dstRect)
+
+	// Opaque pixels are the same in both alpha formats
+	copyAll := sameBlendFunc(blend, SrcBlend) && convert == nil
+	copyOpaque := sameBlendFunc(blend, AlphaBlend)
+	blend = f.nativeBlend(blend)
+
+	// Copy rows bottom-up when copying downwards within the same frame buffer, so that
+	// source rows aren't overwritten before they are read
+	first, last, step := 0, dstRect.Dy()-1, 1
+	if src == f && dstRect.Min.Y > srcRect.Min.Y {
+		first, last, step = last, first, -1
+	}
+
+	for i := first; i != last+step; i += step {
+		srcRow := src.row(srcRect.Min.Y + i)[srcRect.Min.X:srcRect.Max.X]
+		dstRow := f.row(dstRect.Min.Y + i)[dstRect.Min.X:dstRect.Max.X]
+
+		if copyAll || copyOpaque && isOpaque(srcRow) {
+			copy(dstRow, srcRow)
+			continue
+		}
+		if convert != nil {
+			for x := range srcRow {
+				dstRow[x] = blend(convert(srcRow[x]), dstRow[x])
+			}
+			continue
+		}
+		if src == f && dstRect.Min.X > srcRect.Min.X {
+			for x := len(srcRow) - 1; x >= 0; x-- {
+				dstRow[x] = blend(srcRow[x], dstRow[x])
+			}
+			continue
+		}
+		for x := range srcRow {
+			dstRow[x] = blend(srcRow[x], dstRow[x])
+		}
+	}
+}
+
+// BlitScaled draws a rectangular region of the source frame buffer onto this frame
+// buffer, scaled to fill dstRect. The source is resampled using the filter, and pixels
+// are combined using the blend function, or AlphaBlend if nil. The source and
+// destination must not share memory. Pixels are converted if the frame buffers use
+// different alpha formats.
+func (f *FrameBuffer) BlitScaled(src *FrameBuffer, srcRect, dstRect image.Rectangle, filter Filter, blend BlendFunc) {
+	if blend == nil {
+		blend = AlphaBlend
+	}
+
+	if srcRect.Empty() || dstRect.Empty() {
+		return
+	}
+	if srcRect.Size() == dstRect.Size() {
+		f.Blit(src, srcRect, dstRect.Min, blend)
+		return
+	}
+
+	convert := f.convertFrom(src)
+	blend = f.nativeBlend(blend)
+
+	scaleX := float64(srcRect.Dx()) / float64(dstRect.Dx())
+	scaleY := float64(srcRect.Dy()) / float64(dstRect.Dy())
+
+	// Only draw the destination pixels whose centres map onto pixels that exist in the
+	// source. The scale is found first, so that the region keeps its place and size when
+	// it starts or ends outside the source.
+	region := srcRect.Intersect(src.Bounds())
+	if region.Empty() {
+		return
+	}
+	var drawRect image.Rectangle
+	drawRect.Min.X, drawRect.Max.X = scaledSpan(region.Min.X, region.Max.X, srcRect.Min.X, dstRect.Min.X, scaleX)
+	drawRect.Min.Y, drawRect.Max.Y = scaledSpan(region.Min.Y, region.Max.Y, srcRect.Min.Y, dstRect.Min.Y, scaleY)
+	drawRect = drawRect.Intersect(dstRect).Intersect(f.clip)
+	if drawRect.Empty() {
+		return
+	}
+	f.MarkDirty(drawRect)
+	for y := drawRect.Min.Y; y < drawRect.Max.Y; y++ {
+		// Map the centre of each destination pixel into the source region
+		v := float64(srcRect.Min.Y) + (float64(y-dstRect.Min.Y)+0.5)*scaleY
+		dstRow := f.row(y)
+		for x := drawRect.Min.X; x < drawRect.Max.X; x++ {
+			u := float64(srcRect.Min.X) + (float64(x-dstRect.Min.X)+0.5)*scaleX
+			p := src.sample(u, v, region, filter)
+			if convert != nil {
+				p = convert(p)
+			}
+			dstRow[x] = blend(p, dstRow[x])
+		}
+	}
+}
+
+// scaledSpan returns the span of destination pixels, from min to max, whose centres map
+// onto the source pixels from srcMin to srcMax. The source region starting at srcOrigin
+// is drawn from dstOrigin, with scale source pixels per destination pixel.
+func scaledSpan(srcMin, srcMax, srcOrigin, dstOrigin int, scale float64) (int, int) {
+	span := func(s int) int {
+		return dstOrigin + int(math.Ceil(float64(s-srcOrigin)/scale-0.5))
+	}
+	return span(srcMin), span(srcMax)
+}
+
+// sample returns the colour of the frame buffer at continuous coordinates, where pixel
+// (x, y) covers the area from (x, y) to (x+1, y+1). Only pixels within the region are
+// used; coordinates beyond its edges take the colour of the nearest edge pixel. The
+// colour is in the frame buffer's alpha format.
+func (f *FrameBuffer) sample(u, v float64, region image.Rectangle, filter Filter) Pixel {
+	switch filter {
+	case FilterBilinear:
+		x0, fx := splitCoord(u - 0.5)
+		y0, fy := splitCoord(v - 0.5)
+		acc := pixelAccumulator{premultiplied: f.premultiplied}
+		for j, wy := range [2]float64{1 - fy, fy} {
+			for i, wx := range [2]float64{1 - fx, fx} {
+				acc.add(f.clampedPixel(x0+i, y0+j, region), wx*wy)
+			}
+		}
+		return acc.pixel()
+
+	case FilterBicubic:
+		x0, fx := splitCoord(u - 0.5)
+		y0, fy := splitCoord(v - 0.5)
+		wxs, wys := catmullRomWeights(fx), catmullRomWeights(fy)
+		acc := pixelAccumulator{premultiplied: f.premultiplied}
+		for j, wy := range wys {
+			for i, wx := range wxs {
+				acc.add(f.clampedPixel(x0+i-1, y0+j-1, region), wx*wy)
+			}
+		}
+		return acc.pixel()
+
+	default:
+		return f.clampedPixel(int(math.Floor(u)), int(math.Floor(v)), region)
+	}
+}
+
+// clampedPixel returns the pixel at the given coordinates, clamped to lie within the
+// region.
+func (f *FrameBuffer) clampedPixel(x, y int, region image.Rectangle) Pixel {
+	x = Clamp(x, region.Min.X, region.Max.X-1)
+	y = Clamp(y, region.Min.Y, region.Max.Y-1)
+	return f.getPixel(x, y)
+}
+
+// splitCoord splits a coordinate into its integer and fractional parts.
+func splitCoord(c float64) (int, float64) {
+	i := math.Floor(c)
+	return int(i), c - i
+}
+
+// catmullRomWeights returns the weights of the four pixels surrounding a point at
+// fractional offset t, for bicubic interpolation with a Catmull-Rom spline.
+func catmullRomWeights(t float64) [4]float64 {
+	t2 := t * t
+	t3 := t2 * t
+	return [4]float64{
+		-0.5*t3 + t2 - 0.5*t,
+		1.5*t3 - 2.5*t2 + 1.0,
+		-1.5*t3 + 2.0*t2 + 0.5*t,
+		0.5*t3 - 0.5*t2,
+	}
+}
+
+// pixelAccumulator sums weighted pixels. Colour channels are premultiplied by alpha
+// before summing, so that transparent pixels don't bleed their colour into the result.
+type pixelAccumulator struct {
+	r, g, b, a float64
+
+	premultiplied bool // pixels are added and returned with premultiplied alpha
+}
+
+// add adds a pixel to the sum with the given weight.
+func (acc *pixelAccumulator) add(p Pixel, weight float64) {
+	wa := weight * float64(p.A())
+	wc := wa / math.MaxUint8
+	if acc.premultiplied {
+		wc = weight
+	}
+	acc.r += float64(p.R()) * wc
+	acc.g += float64(p.G()) * wc
+	acc.b += float64(p.B()) * wc
+	acc.a += wa
+}
+
+// pixel returns the accumulated pixel.
+func (acc *pixelAccumulator) pixel() Pixel {
+	if acc.a <= 0 {
+		return 0
+	}
+	a := Clamp(math.Round(acc.a), 0, math.MaxUint8)
+	channel := func(c float64) uint8 {
+		if acc.premultiplied {
+			// Premultiplied channels can't exceed the alpha
+			return uint8(Clamp(math.Round(c), 0, a))
+		}
+		return uint8(Clamp(math.Round(c*math.MaxUint8/acc.a), 0, math.MaxUint8))
+	}
+	return pack(uint8(a), channel(acc.b), channel(acc.g), channel(acc.r))
+}
+
+// isOpaque returns true if every pixel in the slice has maximum alpha.
+func isOpaque(pixels []Pixel) bool {
+	for _, p := range pixels {
+		if p.A() != math.MaxUint8 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameBlendFunc returns true if two blend functions refer to the same function.
+func sameBlendFunc(a, b BlendFunc) bool {
+	return blendFuncPointer(a) == blendFuncPointer(b)
+}
+
+// blendFuncPointer returns the address of a blend function's code.
+func blendFuncPointer(blend BlendFunc) uintptr {
+	return reflect.ValueOf(blend).Pointer()
+}
@@ -0,0 +1,121 @@
+package gogl
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBlit(t *testing.T) {
+	src := NewFrameBuffer(4, 4)
+	src.Fill(Red)
+	src.Set(1, 1, color.Transparent)
+
+	dst := NewFrameBuffer(6, 6)
+	dst.Fill(Lime)
+	dst.PushClip(image.Rect(0, 0, 6, 5))
+	dst.Blit(src, image.Rect(1, 1, 4, 4), image.Pt(3, 3), nil)
+
+	for y := range dst.Height() {
+		for x := range dst.Width() {
+			want := NewPixel(Lime)
+			if x >= 4 && x < 6 && y >= 3 && y < 5 || x == 3 && y == 4 {
+				want = NewPixel(Red)
+			}
+			if got := dst.GetPixel(x, y); got != want {
+				t.Errorf("Pixel (%d, %d): expected %#x, got %#x", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestBlitOutsideSource(t *testing.T) {
+	src := NewFrameBuffer(2, 2)
+	src.Fill(Red)
+
+	// Regions which start outside the source keep their pixels in place
+	dst := NewFrameBuffer(8, 8)
+	dst.Blit(src, image.Rect(-2, -2, 2, 2), image.Pt(1, 1), nil)
+	dst.BlitScaled(src, image.Rect(-2, 0, 2, 2), image.Rect(0, 4, 8, 8), FilterNearest, nil)
+
+	for y := range dst.Height() {
+		for x := range dst.Width() {
+			want := Pixel(0)
+			if x >= 3 && x < 5 && y >= 3 && y < 5 || x >= 4 && y >= 4 {
+				want = NewPixel(Red)
+			}
+			if got := dst.GetPixel(x, y); got != want {
+				t.Errorf("Pixel (%d, %d): expected %#x, got %#x", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestBlitOverlapping(t *testing.T) {
+	f := NewFrameBuffer(4, 4)
+	for y := range 4 {
+		for x := range 4 {
+			f.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+
+	// Shifting a frame buffer onto itself should behave as if the source were copied first
+	want := f.clone()
+	want.Blit(f.clone(), image.Rect(0, 0, 3, 3), image.Pt(1, 1), nil)
+	f.Blit(f, image.Rect(0, 0, 3, 3), image.Pt(1, 1), nil)
+
+	for y := range 4 {
+		for x := range 4 {
+			if f.GetPixel(x, y) != want.GetPixel(x, y) {
+				t.Errorf("Pixel (%d, %d): expected %#x, got %#x", x, y, want.GetPixel(x, y), f.GetPixel(x, y))
+			}
+		}
+	}
+}
+
+func TestBlitScaled(t *testing.T) {
+	src := NewFrameBuffer(2, 1)
+	src.Set(0, 0, Black)
+	src.Set(1, 0, White)
+
+	for _, filter := range []Filter{FilterNearest, FilterBilinear, FilterBicubic} {
+		t.Run(filter.String(), func(t *testing.T) {
+			dst := NewFrameBuffer(8, 2)
+			dst.BlitScaled(src, src.Bounds(), dst.Bounds(), filter, nil)
+
+			// The edges should match the source, and the brightness should never decrease
+			// from left to right
+			if got := dst.GetPixel(0, 1); got != NewPixel(Black) {
+				t.Errorf("Expected left edge to be black, got %#x", got)
+			}
+			if got := dst.GetPixel(7, 1); got != NewPixel(White) {
+				t.Errorf("Expected right edge to be white, got %#x", got)
+			}
+			for x := 1; x < dst.Width(); x++ {
+				if dst.GetPixel(x, 0).R() < dst.GetPixel(x-1, 0).R() {
+					t.Errorf("Expected brightness to increase at x=%d", x)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBlitOpaque(b *testing.B) {
+	src := NewFrameBuffer(512, 512)
+	src.Fill(Red)
+	dst := NewFrameBuffer(512, 512)
+
+	for n := 0; n < b.N; n++ {
+		dst.Blit(src, src.Bounds(), image.Point{}, nil)
+	}
+}
+
+func BenchmarkBlitTranslucent(b *testing.B) {
+	src := NewFrameBuffer(512, 512)
+	src.Fill(color.RGBA{255, 0, 0, 128})
+	dst := NewFrameBuffer(512, 512)
+
+	for n := 0; n < b.N; n++ {
+		dst.Blit(src, src.Bounds(), image.Point{}, nil)
+	}
+}
@@ -0,0 +1,151 @@
+package gogl
+
+import "math"
+
+// Bloom is a post effect which makes bright pixels glow. The bright pixels are blurred
+// with a Gaussian blur, and the blur is added on top of the frame buffer.
+//
+// Bloom works with any drawable. To make every drawable on a layer glow, set it as the
+// layer's effect with Layer.SetEffect; to make only some drawables glow, draw them on a
+// layer of their own. Bloom can also be applied straight onto a frame buffer, in which
+// case only its dirty regions glow.
+type Bloom struct {
+	Radius    int     // how far the glow reaches, in pixels
+	Intensity float64 // brightness of the glow; leave 0 for 1
+	Threshold float64 // from 0 to 1, how bright a pixel must be to glow; leave 0 for all pixels
+}
+
+var _ PostEffect = Bloom{}
+
+// Apply implements PostEffect.
+func (b Bloom) Apply(buf *FrameBuffer) {
+	if b.Radius <= 0 {
+		return
+	}
+
+	// Only the dirty regions glow, but the glow reaches beyond them
+	area := buf.dirtyBounds().Inset(-b.Radius).Intersect(buf.clip)
+	if area.Empty() {
+		return
+	}
+
+	// Extract the bright pixels. They are premultiplied so that transparent pixels don't
+	// blur their colour into the glow.
+	w, h := area.Dx(), area.Dy()
+	bright := make([]glowPixel, w*h)
+	for y := range h {
+		for x := range w {
+			p := buf.getPixel(area.Min.X+x, area.Min.Y+y)
+			if !buf.premultiplied {
+				p = p.Premultiply()
+			}
+			if p.A() == 0 {
+				continue
+			}
+
+			// The brightness of the pixel is its largest straight colour channel
+			brightness := float64(max(p.R(), p.G(), p.B())) / float64(p.A())
+			if brightness < b.Threshold {
+				continue
+			}
+			bright[x+w*y] = glowPixel{float32(p.R()), float32(p.G()), float32(p.B()), float32(p.A())}
+		}
+	}
+	glow := gaussianBlur(bright, w, h, b.Radius)
+
+	// Add the glow on top
+	intensity := float32(b.intensity())
+	for y := range h {
+		for x := range w {
+			g := glow[x+w*y]
+			if g.a <= 0 {
+				continue
+			}
+			px, py := area.Min.X+x, area.Min.Y+y
+			old := buf.getPixel(px, py)
+			d := old
+			if !buf.premultiplied {
+				d = d.Premultiply()
+			}
+
+			a := uint8(min(float32(d.A())+g.a*intensity, math.MaxUint8) + 0.5)
+			channel := func(dc uint8, gc float32) uint8 {
+				// Premultiplied channels can't exceed the alpha
+				return uint8(min(float32(dc)+gc*intensity+0.5, float32(a)))
+			}
+			p := pack(a, channel(d.B(), g.b), channel(d.G(), g.g), channel(d.R(), g.r))
+			if !buf.premultiplied {
+				p = p.Unpremultiply()
+			}
+			if p != old {
+				buf.setPixel(px, py, p)
+			}
+		}
+	}
+}
+
+// intensity returns the brightness of the glow, defaulting to 1.
+func (b Bloom) intensity() float64 {
+	if b.Intensity == 0 {
+		return 1
+	}
+	return b.Intensity
+}
+
+// glowPixel is a pixel with premultiplied floating point channels, from 0 to 255.
+type glowPixel struct {
+	r, g, b, a float32
+}
+
+// gaussianBlur returns a copy of an image which is w pixels wide and h pixels tall,
+// blurred with a Gaussian kernel which reaches radius pixels. The blur is separable, so
+// the image is blurred horizontally and then vertically. Pixels beyond the edges of the
+// image are treated as transparent.
+func gaussianBlur(src []glowPixel, w, h, radius int) []glowPixel {
+	kernel := gaussianKernel(radius)
+	blur := func(dst, src []glowPixel, length, lines, step, lineStep int) {
+		for line := range lines {
+			for i := range length {
+				var sum glowPixel
+				for k, weight := range kernel {
+					j := i + k - radius
+					if j < 0 || j >= length {
+						continue
+					}
+					p := src[line*lineStep+j*step]
+					sum.r += p.r * weight
+					sum.g += p.g * weight
+					sum.b += p.b * weight
+					sum.a += p.a * weight
+				}
+				dst[line*lineStep+i*step] = sum
+			}
+		}
+	}
+
+	tmp := make([]glowPixel, len(src))
+	blur(tmp, src, w, h, 1, w)
+	out := make([]glowPixel, len(src))
+	blur(out, tmp, h, w, w, 1)
+	return out
+}
+
+// gaussianKernel returns the weights of a Gaussian kernel which reaches radius pixels
+// either side of its centre, where it fades to about a tenth of its peak. The weights
+// add up to 1.
+func gaussianKernel(radius int) []float32 {
+	sigma := float64(radius) / 2
+	weights := make([]float64, 2*radius+1)
+	var total float64
+	for i := range weights {
+		d := float64(i - radius)
+		weights[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		total += weights[i]
+	}
+
+	kernel := make([]float32, len(weights))
+	for i, w := range weights {
+		kernel[i] = float32(w / total)
+	}
+	return kernel
+}
@@ -0,0 +1,64 @@
+package gogl
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestBloom(t *testing.T) {
+	draw := func(c Pixel) *FrameBuffer {
+		f := NewFrameBuffer(20, 20)
+		f.SetPixel(10, 10, c)
+		return f
+	}
+
+	// The glow reaches about the radius, and is marked dirty
+	f := draw(NewPixel(White))
+	Bloom{Radius: 4}.Apply(f)
+	if got := f.GetPixel(12, 10); got.A() == 0 {
+		t.Error("Expected pixel near the bright pixel to glow")
+	}
+	if got := f.GetPixel(16, 10); got != 0 {
+		t.Errorf("Expected pixel beyond the radius not to glow, got %v", got)
+	}
+	if got := f.GetPixel(10, 10); got != NewPixel(White) {
+		t.Errorf("Expected bright pixel to be unchanged, got %v", got)
+	}
+	dirty := false
+	for _, r := range f.DirtyRects() {
+		dirty = dirty || image.Pt(12, 10).In(r)
+	}
+	if !dirty {
+		t.Error("Expected glow to be marked dirty")
+	}
+
+	// Dim pixels don't glow above the threshold, and intensity brightens the glow
+	f = draw(NewPixel(Navy))
+	Bloom{Radius: 4, Threshold: 0.8}.Apply(f)
+	if got := f.GetPixel(12, 10); got != 0 {
+		t.Errorf("Expected dim pixel not to glow, got %v", got)
+	}
+	dim, bright := draw(NewPixel(White)), draw(NewPixel(White))
+	Bloom{Radius: 4}.Apply(dim)
+	Bloom{Radius: 4, Intensity: 2}.Apply(bright)
+	if dim.GetPixel(12, 10).A() >= bright.GetPixel(12, 10).A() {
+		t.Error("Expected intensity to brighten the glow")
+	}
+}
+
+func TestGaussianKernel(t *testing.T) {
+	for _, radius := range []int{1, 4, 30} {
+		kernel := gaussianKernel(radius)
+		var total float64
+		for _, w := range kernel {
+			total += float64(w)
+		}
+		if len(kernel) != 2*radius+1 || math.Abs(total-1) > 1e-4 {
+			t.Errorf("Expected %d normalised weights, got %d adding up to %f", 2*radius+1, len(kernel), total)
+		}
+		if kernel[0] != kernel[2*radius] || kernel[radius] < kernel[0] {
+			t.Errorf("Expected kernel to be symmetric and peak in the centre, got %v", kernel)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package gogl
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// brush plots the pixels of a shape onto a frame buffer, according to the shape's style.
+// The pixel and blend function are in the frame buffer's alpha format.
+type brush struct {
+	buf     *FrameBuffer
+	pixel   Pixel             // colour of the shape, with the style's opacity applied
+	sample  func(p Vec) Pixel // samples the style's paint; nil for a single colour
+	opacity float64           // opacity applied to sampled colours
+	blend   BlendFunc         // function used to blend the shape onto the frame buffer
+}
+
+// newBrush constructs a brush for drawing a shape with the given style. The style's
+// paint is used if it has one, and its colour otherwise.
+func newBrush(buf *FrameBuffer, style Style) brush {
+	if style.Paint == nil {
+		return newBrushColour(buf, style, style.Colour)
+	}
+	if solid, ok := style.Paint.(SolidPaint); ok {
+		return newBrushColour(buf, style, solid.Colour)
+	}
+	return brush{
+		buf:     buf,
+		sample:  samplePaint(style.Paint),
+		opacity: style.opacity(),
+		blend:   buf.nativeBlend(style.blendFunc()),
+	}
+}
+
+// newBrushColour constructs a brush for drawing a shape with the given style, but with
+// a different colour.
+func newBrushColour(buf *FrameBuffer, style Style, c color.Color) brush {
+	r, g, b, a := RGBA8(c)
+	if opacity := style.opacity(); opacity < 1 {
+		a = uint8(math.Round(float64(a) * opacity))
+	}
+
+	return brush{
+		buf:   buf,
+		pixel: buf.toNative(pack(a, b, g, r)),
+		blend: buf.nativeBlend(style.blendFunc()),
+	}
+}
+
+// pixelAt returns the brush's colour at a pixel.
+func (b brush) pixelAt(x, y int) Pixel {
+	if b.sample == nil {
+		return b.pixel
+	}
+	p := b.sample(Vec{float64(x), float64(y)})
+	if b.opacity < 1 {
+		p = p&^0xff | Pixel(math.Round(float64(p.A())*b.opacity))
+	}
+	return b.buf.toNative(p)
+}
+
+// plot draws a pixel with the brush's colour.
+func (b brush) plot(x, y int) {
+	b.buf.blendPixel(x, y, b.pixelAt(x, y), b.blend)
+}
+
+// plotCoverage draws a pixel with the brush's colour, with its alpha scaled by the
+// coverage, from 0 to 1.
+func (b brush) plotCoverage(x, y int, coverage float64) {
+	p := b.pixelAt(x, y)
+	a := uint8(coverage * float64(p.A()))
+	b.buf.blendPixel(x, y, b.withAlpha(p, a), b.blend)
+}
+
+// plotAlpha draws a pixel with the brush's colour, with its alpha scaled by alpha/255.
+func (b brush) plotAlpha(x, y int, alpha uint8) {
+	p := b.pixelAt(x, y)
+	a := uint8(uint32(p.A()) * uint32(alpha) / math.MaxUint8)
+	b.buf.blendPixel(x, y, b.withAlpha(p, a), b.blend)
+}
+
+// cover draws a pixel with the brush's colour, with its alpha scaled by the coverage,
+// from 0 to 1. Uncovered pixels are skipped.
+func (b brush) cover(x, y int, coverage float64) {
+	switch {
+	case coverage >= 1:
+		b.plot(x, y)
+	case coverage > 0:
+		b.plotCoverage(x, y, coverage)
+	}
+}
+
+// edgeCoverage returns the fraction of a pixel covered by a shape, given the signed
+// distance from the pixel to the shape's edge, which is positive inside the shape.
+func edgeCoverage(dist float64) float64 {
+	return Clamp(dist+0.5, 0, 1)
+}
+
+// withAlpha returns a pixel of the brush with its alpha reduced to a. The colour
+// channels of premultiplied pixels are scaled to match.
+func (b brush) withAlpha(p Pixel, a uint8) Pixel {
+	if !b.buf.premultiplied || p.A() == 0 {
+		return p&^0xff | Pixel(a)
+	}
+	return scalePixel(p, uint32(a)*math.MaxUint8/uint32(p.A()))&^0xff | Pixel(a)
+}
+
+// coverageMask accumulates the coverage of the overlapping parts of a shape, keeping
+// the highest coverage of each pixel, so that every pixel is only drawn once.
+type coverageMask struct {
+	rect     image.Rectangle
+	coverage []float32
+}
+
+// newCoverageMask constructs an empty coverage mask for a region of pixels.
+func newCoverageMask(r image.Rectangle) *coverageMask {
+	return &coverageMask{
+		rect:     r,
+		coverage: make([]float32, r.Dx()*r.Dy()),
+	}
+}
+
+// set raises the coverage of a pixel, from 0 to 1. Pixels outside the mask are ignored.
+func (m *coverageMask) set(x, y int, coverage float64) {
+	if !(image.Point{x, y}.In(m.rect)) {
+		return
+	}
+	i := x - m.rect.Min.X + m.rect.Dx()*(y-m.rect.Min.Y)
+	m.coverage[i] = max(m.coverage[i], float32(coverage))
+}
+
+// addDistance adds a part of a shape described by its signed distance from each pixel,
+// which is positive inside the shape. Only pixels within the bounds are considered.
+func (m *coverageMask) addDistance(bounds image.Rectangle, antiAlias bool, dist func(p Vec) float64) {
+	m.forEach(bounds, func(x, y int, p Vec) {
+		d := dist(p)
+		switch {
+		case antiAlias && d > -0.5:
+			m.set(x, y, edgeCoverage(d))
+		case d >= 0:
+			m.set(x, y, 1)
+		}
+	})
+}
+
+// forEach calls fn for each pixel of the mask within the bounds.
+func (m *coverageMask) forEach(bounds image.Rectangle, fn func(x, y int, p Vec)) {
+	area := bounds.Intersect(m.rect)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			fn(x, y, Vec{float64(x), float64(y)})
+		}
+	}
+}
+
+// draw draws every covered pixel of the mask with the brush.
+func (m *coverageMask) draw(b brush) {
+	i := 0
+	for y := m.rect.Min.Y; y < m.rect.Max.Y; y++ {
+		for x := m.rect.Min.X; x < m.rect.Max.X; x++ {
+			b.cover(x, y, float64(m.coverage[i]))
+			i++
+		}
+	}
+}
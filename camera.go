@@ -0,0 +1,252 @@
+package gogl
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+)
+
+// Camera maps between world coordinates and a viewport on the window. The camera looks
+// at a point in the world, which is shown at the centre of the viewport, and can zoom
+// and rotate about it.
+type Camera struct {
+	pos      Vec
+	zoom     float64
+	rotation float64
+	viewport image.Rectangle
+
+	target    interface{ GetPos() Vec }
+	smoothing time.Duration
+
+	clamped              bool
+	boundsMin, boundsMax Vec
+}
+
+// NewCamera constructs a camera which draws onto a viewport of the window. It starts
+// looking at the centre of the viewport, without zoom or rotation, so that world and
+// window coordinates are the same.
+func NewCamera(viewport image.Rectangle) *Camera {
+	return &Camera{
+		pos: Vec{
+			X: float64(viewport.Min.X+viewport.Max.X) / 2,
+			Y: float64(viewport.Min.Y+viewport.Max.Y) / 2,
+		},
+		zoom:     1,
+		viewport: viewport,
+	}
+}
+
+// Pos returns the world point at the centre of the viewport.
+func (c *Camera) Pos() Vec {
+	return c.pos
+}
+
+// SetPos sets the world point at the centre of the viewport.
+func (c *Camera) SetPos(pos Vec) *Camera {
+	c.pos = pos
+	c.clamp()
+	return c
+}
+
+// Move pans the camera by a distance in world coordinates.
+func (c *Camera) Move(v Vec) *Camera {
+	return c.SetPos(Add(c.pos, v))
+}
+
+// Zoom returns the camera's zoom, which is the number of window pixels per world unit.
+func (c *Camera) Zoom() float64 {
+	return c.zoom
+}
+
+// SetZoom sets the camera's zoom, which is the number of window pixels per world unit.
+// It panics if the zoom isn't positive.
+func (c *Camera) SetZoom(zoom float64) *Camera {
+	if zoom <= 0 {
+		panic(fmt.Sprintf("camera zoom must be positive, got %g", zoom))
+	}
+	c.zoom = zoom
+	c.clamp()
+	return c
+}
+
+// Rotation returns the camera's rotation in radians.
+func (c *Camera) Rotation() float64 {
+	return c.rotation
+}
+
+// SetRotation sets the camera's rotation in radians. Turning the camera clockwise turns
+// the world anticlockwise on screen.
+func (c *Camera) SetRotation(theta float64) *Camera {
+	c.rotation = theta
+	c.clamp()
+	return c
+}
+
+// Viewport returns the region of the window which the camera draws onto.
+func (c *Camera) Viewport() image.Rectangle {
+	return c.viewport
+}
+
+// SetViewport sets the region of the window which the camera draws onto.
+func (c *Camera) SetViewport(r image.Rectangle) *Camera {
+	c.viewport = r
+	c.clamp()
+	return c
+}
+
+// SetBounds stops the camera showing anything outside of a region of the world. If the
+// region is smaller than the view, the camera is centred on it.
+func (c *Camera) SetBounds(min, max Vec) *Camera {
+	c.clamped, c.boundsMin, c.boundsMax = true, min, max
+	c.clamp()
+	return c
+}
+
+// ClearBounds lets the camera show the whole world.
+func (c *Camera) ClearBounds() *Camera {
+	c.clamped = false
+	return c
+}
+
+// Follow makes the camera move towards a target's position on each call to Update. The
+// smoothing is roughly how long the camera takes to catch up; the camera jumps straight
+// to the target if it is 0. Pass a nil target to stop following.
+func (c *Camera) Follow(target interface{ GetPos() Vec }, smoothing time.Duration) *Camera {
+	c.target, c.smoothing = target, smoothing
+	return c
+}
+
+// Update moves the camera towards its target, if it has one, after a period of time.
+func (c *Camera) Update(dt time.Duration) {
+	if c.target == nil {
+		return
+	}
+	target := c.target.GetPos()
+	if c.smoothing <= 0 {
+		c.SetPos(target)
+		return
+	}
+
+	// Close a fixed fraction of the gap per unit of time, so that the camera moves the same
+	// way whatever the frame rate
+	t := 1 - math.Exp(-dt.Seconds()/c.smoothing.Seconds())
+	d := Sub(target, c.pos)
+	c.Move(Vec{X: d.X * t, Y: d.Y * t})
+}
+
+// Transform returns the transform from world coordinates to window coordinates.
+func (c *Camera) Transform() Transform {
+	centre := Vec{
+		X: float64(c.viewport.Min.X+c.viewport.Max.X) / 2,
+		Y: float64(c.viewport.Min.Y+c.viewport.Max.Y) / 2,
+	}
+	return Translate(centre).
+		Mul(Scale(c.zoom, c.zoom)).
+		Mul(Rotate(-c.rotation)).
+		Mul(Translate(Vec{X: -c.pos.X, Y: -c.pos.Y}))
+}
+
+// WorldToScreen maps a point in the world to a point on the window.
+func (c *Camera) WorldToScreen(p Vec) Vec {
+	return c.Transform().Apply(p)
+}
+
+// ScreenToWorld maps a point on the window, such as the mouse location, to a point in
+// the world.
+func (c *Camera) ScreenToWorld(p Vec) Vec {
+	// The zoom is positive, so the transform can always be inverted
+	inv, _ := c.Transform().Invert()
+	return inv.Apply(p)
+}
+
+// clamp moves the camera so that the view stays within its bounds.
+func (c *Camera) clamp() {
+	if !c.clamped {
+		return
+	}
+
+	// Find the half size of the world region which the rotated viewport covers
+	sin, cos := math.Sincos(c.rotation)
+	w := float64(c.viewport.Dx()) / (2 * c.zoom)
+	h := float64(c.viewport.Dy()) / (2 * c.zoom)
+	extent := Vec{
+		X: math.Abs(cos)*w + math.Abs(sin)*h,
+		Y: math.Abs(sin)*w + math.Abs(cos)*h,
+	}
+
+	clampAxis := func(pos, lo, hi, extent float64) float64 {
+		if hi-lo < 2*extent {
+			return (lo + hi) / 2
+		}
+		return Clamp(pos, lo+extent, hi-extent)
+	}
+	c.pos.X = clampAxis(c.pos.X, c.boundsMin.X, c.boundsMax.X, extent.X)
+	c.pos.Y = clampAxis(c.pos.Y, c.boundsMin.Y, c.boundsMax.Y, extent.Y)
+}
+
+// view returns the drawables as they are seen through the camera. Drawables which can be
+// transformed, and scene graphs, are drawn in world coordinates, confined to the viewport.
+// Other drawables, such as buttons, are drawn in window coordinates.
+func (c *Camera) view(queue []Drawable) []Drawable {
+	m := c.Transform()
+	out := make([]Drawable, 0, len(queue))
+	for _, d := range queue {
+		v, ok := d.(viewable)
+		if !ok {
+			out = append(out, d)
+			continue
+		}
+		// Scene graphs are split into their drawables, so that they can be drawn in parallel
+		viewed := v.viewed(m, 1)
+		list, ok := viewed.(drawableList)
+		if !ok {
+			list = drawableList{viewed}
+		}
+		for _, d := range list {
+			out = append(out, c.confine(d))
+		}
+	}
+	return out
+}
+
+// confine returns a drawable which is confined to the camera's viewport.
+func (c *Camera) confine(d Drawable) Drawable {
+	v := viewportDrawable{d, c.viewport}
+	if _, ok := d.(bounded); ok {
+		return boundedViewportDrawable{v}
+	}
+	return v
+}
+
+// viewable is a drawable which can be drawn through a camera.
+type viewable interface {
+	Drawable
+	// viewed returns a copy of the drawable with a view transform applied after its own,
+	// and its opacity scaled.
+	viewed(view Transform, opacity float64) Drawable
+}
+
+// viewportDrawable is a drawable which is confined to a viewport.
+type viewportDrawable struct {
+	d        Drawable
+	viewport image.Rectangle
+}
+
+// Draw implements Drawable.
+func (v viewportDrawable) Draw(buf *FrameBuffer) {
+	buf.PushClip(v.viewport)
+	v.d.Draw(buf)
+	buf.PopClip()
+}
+
+// boundedViewportDrawable is a drawable which is confined to a viewport, and reports its
+// bounds so that it can be drawn in parallel.
+type boundedViewportDrawable struct {
+	viewportDrawable
+}
+
+// Bounds returns the pixel bounding box of the drawable within the viewport.
+func (v boundedViewportDrawable) Bounds() image.Rectangle {
+	return v.d.(bounded).Bounds().Intersect(v.viewport)
+}
@@ -0,0 +1,157 @@
+package gogl
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCameraTransform(t *testing.T) {
+	cam := NewCamera(image.Rect(0, 0, 100, 80))
+	if got := cam.WorldToScreen(Vec{10, 20}); !vecNear(got, Vec{10, 20}) {
+		t.Errorf("Expected a new camera to leave points where they are, got %v", got)
+	}
+
+	cam.SetPos(Vec{200, 100}).SetZoom(2)
+	for _, tc := range []struct {
+		world, screen Vec
+	}{
+		{Vec{200, 100}, Vec{50, 40}},
+		{Vec{210, 100}, Vec{70, 40}},
+		{Vec{200, 90}, Vec{50, 20}},
+	} {
+		if got := cam.WorldToScreen(tc.world); !vecNear(got, tc.screen) {
+			t.Errorf("Expected %v to be shown at %v, got %v", tc.world, tc.screen, got)
+		}
+		if got := cam.ScreenToWorld(tc.screen); !vecNear(got, tc.world) {
+			t.Errorf("Expected %v to map back to %v, got %v", tc.screen, tc.world, got)
+		}
+	}
+
+	// Turning the camera clockwise turns the world anticlockwise
+	cam.SetZoom(1).SetRotation(math.Pi / 2)
+	if got := cam.WorldToScreen(Vec{210, 100}); !vecNear(got, Vec{50, 30}) {
+		t.Errorf("Expected point to the right of the camera to be shown above the centre, got %v", got)
+	}
+}
+
+func TestCameraFollow(t *testing.T) {
+	target := NewCircle(1, Vec{100, 0})
+	cam := NewCamera(image.Rect(-10, -10, 10, 10)).Follow(target, time.Second)
+
+	// Each second closes the same fraction of the gap, whatever the time step
+	cam.Update(time.Second)
+	stepped := NewCamera(image.Rect(-10, -10, 10, 10)).Follow(target, time.Second)
+	for range 10 {
+		stepped.Update(100 * time.Millisecond)
+	}
+	want := 100 * (1 - math.Exp(-1))
+	if math.Abs(cam.Pos().X-want) > 1e-9 || math.Abs(stepped.Pos().X-want) > 1e-9 {
+		t.Errorf("Expected camera to move to %v, got %v and %v", want, cam.Pos().X, stepped.Pos().X)
+	}
+
+	cam.Follow(target, 0).Update(time.Millisecond)
+	if cam.Pos() != target.GetPos() {
+		t.Errorf("Expected camera to jump to %v, got %v", target.GetPos(), cam.Pos())
+	}
+}
+
+func TestCameraBounds(t *testing.T) {
+	cam := NewCamera(image.Rect(0, 0, 20, 10)).SetBounds(Vec{0, 0}, Vec{100, 50})
+
+	cam.SetPos(Vec{-50, 200})
+	if want := (Vec{10, 45}); cam.Pos() != want {
+		t.Errorf("Expected camera to be clamped to %v, got %v", want, cam.Pos())
+	}
+
+	// Zooming out shows more of the world, so the camera is pushed further in
+	cam.SetZoom(0.5)
+	if want := (Vec{20, 40}); cam.Pos() != want {
+		t.Errorf("Expected camera to be clamped to %v, got %v", want, cam.Pos())
+	}
+
+	// The view is larger than the bounds, so the camera is centred on them
+	cam.SetZoom(0.1)
+	if want := (Vec{50, 25}); !vecNear(cam.Pos(), want) {
+		t.Errorf("Expected camera to be centred at %v, got %v", want, cam.Pos())
+	}
+
+	cam.ClearBounds().SetPos(Vec{-50, 200})
+	if want := (Vec{-50, 200}); cam.Pos() != want {
+		t.Errorf("Expected camera to move freely to %v, got %v", want, cam.Pos())
+	}
+}
+
+func TestWindowCamera(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 40, 40)
+	cam := NewCamera(image.Rect(0, 0, 20, 40)).SetPos(Vec{100, 100}).SetZoom(2)
+	win.SetCamera(cam)
+
+	// The square is drawn around the centre of the viewport, and clipped to it
+	win.SetBackground(Black)
+	win.Draw(NewRect(10, 10, Vec{95, 95}).SetStyle(Style{Colour: Red}))
+	win.Update()
+	for _, tc := range []struct {
+		x, y int
+		want Pixel
+	}{
+		{10, 20, NewPixel(Red)},
+		{1, 11, NewPixel(Red)},
+		{19, 29, NewPixel(Red)},
+		{1, 9, NewPixel(Black)},
+		{20, 20, NewPixel(Black)},
+	} {
+		if got := backend.Frame().GetPixel(tc.x, tc.y); got != tc.want {
+			t.Errorf("Expected pixel (%d,%d) to be %v, got %v", tc.x, tc.y, tc.want, got)
+		}
+	}
+
+	backend.SetMouse(Vec{10, 30}, NoClick)
+	if got, want := win.MouseWorldLocation(), (Vec{100, 105}); !vecNear(got, want) {
+		t.Errorf("Expected mouse to be at %v in the world, got %v", want, got)
+	}
+
+	win.SetCamera(nil)
+	if got := win.ScreenToWorld(Vec{10, 30}); got != (Vec{10, 30}) {
+		t.Errorf("Expected no camera to leave points where they are, got %v", got)
+	}
+}
+
+func TestCameraScene(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 40, 40)
+	cam := NewCamera(image.Rect(0, 0, 40, 40)).SetPos(Vec{30, 20})
+	win.SetCamera(cam)
+
+	// Drawables which can't be transformed move with the rest of the scene
+	dot := func(x, y int, c Pixel) Drawable {
+		return barrierFunc(func(buf *FrameBuffer) { buf.SetPixel(x, y, c) })
+	}
+	root := NewNode(NewRect(2, 2, Vec{14, 4}).SetStyle(Style{Colour: Red})).
+		Add(NewNode(dot(15, 5, NewPixel(Blue))).SetTransform(Translate(Vec{1, 0})))
+	win.SetScene(root)
+	win.Draw(NewNode(dot(20, 10, NewPixel(Lime))))
+	win.Update()
+
+	for _, tc := range []struct {
+		x, y int
+		want Pixel
+	}{
+		{5, 5, NewPixel(Red)},
+		{6, 5, NewPixel(Blue)},
+		{10, 10, NewPixel(Lime)},
+		{16, 5, 0},
+		{20, 10, 0},
+	} {
+		if got := backend.Frame().GetPixel(tc.x, tc.y); got != tc.want {
+			t.Errorf("Expected pixel (%d,%d) to be %v, got %v", tc.x, tc.y, tc.want, got)
+		}
+	}
+
+	// The scene follows the camera as it moves
+	cam.SetPos(Vec{29, 20})
+	win.Update()
+	if got := backend.Frame().GetPixel(7, 5); got != NewPixel(Blue) {
+		t.Errorf("Expected pixel to follow the camera, got %v", got)
+	}
+}
@@ -1,6 +1,8 @@
 package gogl
 
 import (
+	"fmt"
+	"image"
 	"image/color"
 	"math"
 )
@@ -11,6 +13,7 @@ type Circle struct {
 	Direction Vec
 	d         float64
 	style     Style
+	transformable
 }
 
 var _ Shape = (*Circle)(nil)
@@ -28,11 +31,34 @@ func NewCircle(diameter float64, pos Vec) *Circle {
 
 // IsWithin returns whether a position lies within the circle's perimeter.
 func (c *Circle) IsWithin(pos Vec) bool {
-	return Dist(c.Pos, pos) <= c.Width()/2
+	pos, ok := c.untransform(pos, c.Pos)
+	return ok && Dist(c.Pos, pos) <= c.Width()/2
+}
+
+// Bounds returns the pixel bounding box of the circle.
+func (c *Circle) Bounds() image.Rectangle {
+	if c.transformed {
+		return c.transformedPath().Bounds()
+	}
+	r := c.d / 2
+	return pixelBounds(c.Pos.X-r, c.Pos.Y-r, c.Pos.X+r, c.Pos.Y+r)
 }
 
 // Draw draws the circle onto the provided frame buffer.
 func (c *Circle) Draw(buf *FrameBuffer) {
+	if c.transformed {
+		c.transformedPath().Draw(buf)
+		return
+	}
+	if buf.isClipped(c.Bounds()) {
+		return
+	}
+
+	if c.style.AntiAlias {
+		c.drawAntiAliased(buf)
+		return
+	}
+
 	thickness := c.style.Thickness
 	if c.style.Thickness == 0 { // for filled shape
 		thickness = c.d / 2
@@ -46,32 +72,77 @@ func (c *Circle) Draw(buf *FrameBuffer) {
 	// TODO: this could be faster by calculating a quarter of the circle and mirroring it
 	// onto the other quadrants
 
-	// Iterate over every pixel in the bounding box
-	for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-		for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
+	// Iterate over every pixel in the bounding box that can be drawn
+	b := newBrush(buf, c.style)
+	x0, x1 := clipSteps(bbox.Pos.X, bbox.Pos.X+bbox.w, buf.clip.Min.X, buf.clip.Max.X, truncPixel)
+	y0, y1 := clipSteps(bbox.Pos.Y, bbox.Pos.Y+bbox.h, buf.clip.Min.Y, buf.clip.Max.Y, truncPixel)
+	for i := x0; i < x1; i++ {
+		x := bbox.Pos.X + float64(i)
+		for j := y0; j < y1; j++ {
+			y := bbox.Pos.Y + float64(j)
+
 			// Draw pixel if it's close enough to centre
 			dist := Dist(c.Pos, Vec{x, y})
 			if dist >= float64(radius-thickness) && dist <= float64(radius) {
-				buf.SetPixel(int(x), int(y), NewPixel(c.style.Colour))
+				b.plot(int(x), int(y))
 			}
 		}
 	}
+}
 
-	// Draw bloom if it exists
-	if c.style.Bloom > 0 {
-		c.drawBloom(buf)
+// SVG returns the circle as an SVG element. Outlines are drawn inside the edge of the
+// circle, as they are on the frame buffer.
+func (c *Circle) SVG() string {
+	radius := c.d / 2
+	thickness := c.style.Thickness
+	if thickness > 0 && thickness < radius {
+		radius -= thickness / 2
+	} else {
+		thickness = 0
 	}
+	return fmt.Sprintf(`<circle cx="%s" cy="%s" r="%s"%s%s/>`,
+		svgNum(c.Pos.X), svgNum(c.Pos.Y), svgNum(radius), svgPaint(c.style, thickness), c.svgTransform(c.Pos))
+}
+
+// SetTransform sets a transform which is applied about the centre of the circle.
+func (c *Circle) SetTransform(t Transform) *Circle {
+	c.setTransform(t)
+	return c
+}
+
+// viewed returns a copy of the circle with a view transform applied after its own,
+// and its opacity scaled.
+func (c *Circle) viewed(view Transform, opacity float64) Drawable {
+	v := *c
+	v.transformable = c.withView(view, c.Pos)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// transformedPath returns the circle as a path, with its transform applied.
+func (c *Circle) transformedPath() *Path {
+	return outlinePath(c.style, c.matrix(c.Pos), func(p *Path, inset float64) {
+		if r := c.d/2 - inset; r > 0 {
+			p.addEllipse(c.Pos, r, r)
+		}
+	})
 }
 
 // DrawCircleSegment draws only a segment of the circle to the frame buffer, limited by the
 // provided vector.
 func (c *Circle) DrawCircleSegment(limitDir Vec, buf *FrameBuffer) {
+	if buf.isClipped(c.Bounds()) {
+		return
+	}
+
 	// Construct bounding box
 	radius := c.d / 2
 	bbBoxPos := Vec{c.Pos.X - (radius), c.Pos.Y - (radius)}
 	bbox := NewRect(c.d, c.d, bbBoxPos)
 
 	// Iterate over every pixel in the bounding box
+	b := newBrush(buf, c.style)
+	outline := newBrushColour(buf, c.style, color.White)
 	for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
 		for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
 			// Draw pixel if it's close enough to centre
@@ -80,13 +151,13 @@ func (c *Circle) DrawCircleSegment(limitDir Vec, buf *FrameBuffer) {
 			if c.style.Thickness == 0 {
 				// Solid fill
 				if dist <= float64(radius) && Theta(c.Direction, Sub(Vec{x, y}, c.Pos)) >= 0 {
-					buf.SetPixel(iInt, jInt, NewPixel(c.style.Colour))
+					b.plot(iInt, jInt)
 				}
 			} else {
 				// Outline
 				if dist >= float64(radius-c.style.Thickness) && dist <= float64(radius) &&
 					Theta(c.Direction, Sub(Vec{x, y}, c.Pos)) >= Theta(Upwards, limitDir) {
-					buf.SetPixel(iInt, jInt, NewPixel(color.White))
+					outline.plot(iInt, jInt)
 				}
 			}
 		}
@@ -146,24 +217,23 @@ func (c *Circle) String() string {
 	return "circle"
 }
 
-// drawBloom draws a bloom effect around a circle.
-func (c *Circle) drawBloom(buf *FrameBuffer) {
-	bloom := float64(c.style.Bloom)
-
-	// Construct bounding box
+// drawAntiAliased draws the circle with smooth edges, by scaling the alpha of each
+// pixel by how much of it the circle covers.
+func (c *Circle) drawAntiAliased(buf *FrameBuffer) {
 	radius := c.d / 2
-	bbBoxPos := Vec{c.Pos.X - radius - bloom, c.Pos.Y - radius - bloom}
-	bbox := NewRect(c.d+bloom+bloom, c.d+bloom+bloom, bbBoxPos)
-
-	// Iterate over every pixel in the bounding box
-	r, g, b, a := RGBA8(c.style.Colour)
-	for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-		for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
-			dist := Dist(c.Pos, Vec{x, y})
-			if dist >= radius && dist <= radius+bloom {
-				brightness := 1 - ((dist - radius) / bloom)
-				bloomColour := color.RGBA{r, g, b, uint8(brightness * float64(a))}
-				buf.SetPixel(int(x), int(y), NewPixel(bloomColour))
+	area := c.Bounds().Intersect(buf.clip)
+
+	b := newBrush(buf, c.style)
+	for x := area.Min.X; x < area.Max.X; x++ {
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			dist := Dist(c.Pos, Vec{float64(x), float64(y)})
+			coverage := edgeCoverage(radius - dist)
+			if c.style.Thickness > 0 && c.style.Thickness < radius {
+				// Remove the hole in the middle of the outline
+				coverage -= edgeCoverage(radius - c.style.Thickness - dist)
+			}
+			if coverage > 0 {
+				b.plotCoverage(x, y, coverage)
 			}
 		}
 	}
@@ -0,0 +1,101 @@
+package gogl
+
+import (
+	"image"
+	"math"
+)
+
+// PushClip confines drawing to a rectangular region of the frame buffer, until the
+// matching call to PopClip. If a clip region is already active, the new region is
+// intersected with it, so nested clips can only shrink the drawable area.
+func (f *FrameBuffer) PushClip(r image.Rectangle) {
+	f.clipStack = append(f.clipStack, f.clip)
+	f.clip = f.clip.Intersect(r)
+}
+
+// PopClip restores the clip region that was active before the most recent call to
+// PushClip.
+func (f *FrameBuffer) PopClip() {
+	if len(f.clipStack) == 0 {
+		panic("PopClip called without a matching PushClip")
+	}
+	f.clip = f.clipStack[len(f.clipStack)-1]
+	f.clipStack = f.clipStack[:len(f.clipStack)-1]
+}
+
+// Clip returns the current clip region. Without any clips, this is the bounds of the
+// frame buffer.
+func (f *FrameBuffer) Clip() image.Rectangle {
+	return f.clip
+}
+
+// inClip returns true if the pixel lies within the current clip region.
+func (f *FrameBuffer) inClip(x, y int) bool {
+	return x >= f.clip.Min.X && x < f.clip.Max.X && y >= f.clip.Min.Y && y < f.clip.Max.Y
+}
+
+// isClipped returns true if nothing within the rectangle can be drawn, because it lies
+// entirely outside of the clip region.
+func (f *FrameBuffer) isClipped(r image.Rectangle) bool {
+	return !r.Overlaps(f.clip)
+}
+
+// pixelBounds returns a pixel rectangle which contains every pixel that a shape spanning
+// the given coordinates could draw to. A one pixel margin allows for rounding.
+func pixelBounds(minX, minY, maxX, maxY float64) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(minX))-1, int(math.Floor(minY))-1,
+		int(math.Ceil(maxX))+2, int(math.Ceil(maxY))+2,
+	)
+}
+
+// clipSteps narrows a loop over the coordinates start, start+1, start+2... up to end, so
+// that it only visits coordinates whose pixels lie between lo (inclusive) and hi
+// (exclusive). It returns the range of steps to take, from first up to but not
+// including last. toPixel converts a coordinate to a pixel, and must never decrease as
+// the coordinate increases.
+func clipSteps(start, end float64, lo, hi int, toPixel func(float64) int) (first, last int) {
+	n := int(math.Floor(end-start)) + 1
+	if n <= 0 {
+		return 0, 0
+	}
+
+	// Jump close to the clip region, then step to its exact edges
+	first = Clamp(int(math.Floor(float64(lo)-start))-1, 0, n)
+	for first < n && toPixel(start+float64(first)) < lo {
+		first++
+	}
+	last = Clamp(int(math.Ceil(float64(hi)-start))+1, first, n)
+	for last > first && toPixel(start+float64(last-1)) >= hi {
+		last--
+	}
+	return first, last
+}
+
+// truncPixel converts a coordinate to a pixel by discarding the fractional part.
+func truncPixel(v float64) int {
+	return int(v)
+}
+
+// roundPixel converts a coordinate to the nearest pixel.
+func roundPixel(v float64) int {
+	return int(math.Round(v))
+}
+
+// clipView returns a frame buffer which shares memory and coordinates with this frame
+// buffer, but with drawing confined to the part of the clip region within r. Views with
+// separate clip regions can be drawn onto concurrently, as long as the regions don't
+// overlap. The view tracks dirty regions separately, so they must be merged back with
+// mergeDirty.
+func (f *FrameBuffer) clipView(r image.Rectangle) *FrameBuffer {
+	view := *f
+	view.clip = f.clip.Intersect(r)
+	view.clipStack = nil
+	view.dirty = f.dirty.blank()
+	return &view
+}
+
+// mergeDirty marks the regions which are dirty in a view from clipView as dirty.
+func (f *FrameBuffer) mergeDirty(view *FrameBuffer) {
+	f.dirty.merge(view.dirty)
+}
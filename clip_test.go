@@ -0,0 +1,58 @@
+package gogl
+
+import (
+	"image"
+	"testing"
+)
+
+func TestClipStack(t *testing.T) {
+	f := NewFrameBuffer(20, 20)
+
+	f.PushClip(image.Rect(2, 2, 12, 12))
+	f.PushClip(image.Rect(8, 0, 20, 10))
+	if want := image.Rect(8, 2, 12, 10); f.Clip() != want {
+		t.Errorf("Expected nested clip %v, got %v", want, f.Clip())
+	}
+
+	f.PopClip()
+	if want := image.Rect(2, 2, 12, 12); f.Clip() != want {
+		t.Errorf("Expected clip %v after pop, got %v", want, f.Clip())
+	}
+
+	f.PopClip()
+	if f.Clip() != f.Bounds() {
+		t.Errorf("Expected clip to be reset to %v, got %v", f.Bounds(), f.Clip())
+	}
+}
+
+func TestClipDrawing(t *testing.T) {
+	f := NewFrameBuffer(20, 20)
+	clip := image.Rect(5, 5, 15, 15)
+	f.PushClip(clip)
+
+	f.Fill(White)
+	NewCircle(30, Vec{10, 10}).SetStyle(Style{Colour: Red}).Draw(f)
+	DrawLine(Vec{0, 0}, Vec{19, 19}, f)
+	NewText("text", Vec{0, 0}, "fonts/luxisr.ttf").Draw(f)
+	Bloom{Radius: 4}.Apply(f)
+
+	for y := range f.Height() {
+		for x := range f.Width() {
+			inside := image.Pt(x, y).In(clip)
+			if got := f.GetPixel(x, y); !inside && got != 0 {
+				t.Fatalf("Expected pixel (%d, %d) outside of clip to be untouched, got %#x", x, y, got)
+			} else if inside && got == 0 {
+				t.Fatalf("Expected pixel (%d, %d) inside of clip to be drawn", x, y)
+			}
+		}
+	}
+}
+
+func TestPopClipWithoutPush(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected PopClip to panic")
+		}
+	}()
+	NewFrameBuffer(1, 1).PopClip()
+}
@@ -0,0 +1,161 @@
+package gogl
+
+import "image"
+
+// dirtyCellSize is the width and height of the cells which dirty regions are tracked in,
+// in pixels.
+const dirtyCellSize = 32
+
+// dirtyTracker records which cells of a frame buffer have been written to. Cells are
+// square, so dirty regions are rounded outwards to whole cells.
+type dirtyTracker struct {
+	cells      []bool
+	cols, rows int
+}
+
+// newDirtyTracker constructs a dirty tracker for a frame buffer with a particular width
+// and height, where every cell starts off dirty.
+func newDirtyTracker(width, height int) *dirtyTracker {
+	d := &dirtyTracker{
+		cols: (width + dirtyCellSize - 1) / dirtyCellSize,
+		rows: (height + dirtyCellSize - 1) / dirtyCellSize,
+	}
+	d.cells = make([]bool, d.cols*d.rows)
+	for i := range d.cells {
+		d.cells[i] = true
+	}
+	return d
+}
+
+// blank returns a dirty tracker of the same size, where every cell is clean.
+func (d *dirtyTracker) blank() *dirtyTracker {
+	return &dirtyTracker{
+		cells: make([]bool, len(d.cells)),
+		cols:  d.cols,
+		rows:  d.rows,
+	}
+}
+
+// mark marks the cell containing a pixel as dirty.
+func (d *dirtyTracker) mark(x, y int) {
+	d.cells[x/dirtyCellSize+d.cols*(y/dirtyCellSize)] = true
+}
+
+// cellRange returns the range of cells which overlap a pixel rectangle.
+func (d *dirtyTracker) cellRange(r image.Rectangle) image.Rectangle {
+	return image.Rect(
+		r.Min.X/dirtyCellSize, r.Min.Y/dirtyCellSize,
+		(r.Max.X+dirtyCellSize-1)/dirtyCellSize, (r.Max.Y+dirtyCellSize-1)/dirtyCellSize,
+	).Intersect(image.Rect(0, 0, d.cols, d.rows))
+}
+
+// set sets the dirty state of every cell which overlaps a pixel rectangle.
+func (d *dirtyTracker) set(r image.Rectangle, dirty bool) {
+	cells := d.cellRange(r)
+	for cy := cells.Min.Y; cy < cells.Max.Y; cy++ {
+		row := d.cells[d.cols*cy:]
+		for cx := cells.Min.X; cx < cells.Max.X; cx++ {
+			row[cx] = dirty
+		}
+	}
+}
+
+// merge marks every cell which is dirty in another tracker of the same size as dirty.
+func (d *dirtyTracker) merge(other *dirtyTracker) {
+	for i, dirty := range other.cells {
+		if dirty {
+			d.cells[i] = true
+		}
+	}
+}
+
+// rects returns the dirty parts of a pixel rectangle, as a list of non-overlapping
+// rectangles. Horizontal runs of dirty cells are joined together, and runs which span
+// the same columns in consecutive rows are joined into a single rectangle.
+func (d *dirtyTracker) rects(r image.Rectangle) []image.Rectangle {
+	cells := d.cellRange(r)
+
+	var rects []image.Rectangle
+	var open []int // indices of rectangles which reach the previous row of cells
+	for cy := cells.Min.Y; cy < cells.Max.Y; cy++ {
+		var next []int
+		row := d.cells[d.cols*cy:]
+		for cx := cells.Min.X; cx < cells.Max.X; cx++ {
+			if !row[cx] {
+				continue
+			}
+			start := cx
+			for cx < cells.Max.X && row[cx] {
+				cx++
+			}
+			run := image.Rect(
+				start*dirtyCellSize, cy*dirtyCellSize,
+				cx*dirtyCellSize, (cy+1)*dirtyCellSize,
+			)
+
+			// Extend the rectangle above if it spans the same columns
+			extended := false
+			for _, i := range open {
+				if rects[i].Min.X == run.Min.X && rects[i].Max.X == run.Max.X {
+					rects[i].Max.Y = run.Max.Y
+					next = append(next, i)
+					extended = true
+					break
+				}
+			}
+			if !extended {
+				rects = append(rects, run)
+				next = append(next, len(rects)-1)
+			}
+		}
+		open = next
+	}
+
+	for i := range rects {
+		rects[i] = rects[i].Intersect(r)
+	}
+	return rects
+}
+
+// DirtyRects returns the regions of the frame buffer which have been drawn onto since
+// it was created or ClearDirty was last called. Regions are tracked in 32x32 pixel
+// cells, so they may include some pixels which didn't change.
+//
+// Drawing through Bytes isn't tracked; use MarkDirty to record it.
+func (f *FrameBuffer) DirtyRects() []image.Rectangle {
+	rects := f.dirty.rects(f.Bounds().Add(f.dirtyOffset))
+	for i := range rects {
+		rects[i] = rects[i].Sub(f.dirtyOffset)
+	}
+	return rects
+}
+
+// dirtyBounds returns the smallest rectangle which contains all of the dirty regions.
+func (f *FrameBuffer) dirtyBounds() image.Rectangle {
+	var area image.Rectangle
+	for _, r := range f.DirtyRects() {
+		area = area.Union(r)
+	}
+	return area
+}
+
+// IsDirty returns true if any part of the frame buffer has been drawn onto since it was
+// created or ClearDirty was last called.
+func (f *FrameBuffer) IsDirty() bool {
+	return len(f.DirtyRects()) > 0
+}
+
+// MarkDirty marks a region of the frame buffer as dirty.
+func (f *FrameBuffer) MarkDirty(r image.Rectangle) {
+	r = r.Intersect(f.Bounds())
+	if r.Empty() {
+		return
+	}
+	f.dirty.set(r.Add(f.dirtyOffset), true)
+}
+
+// ClearDirty marks the whole frame buffer as clean. For views, this also affects the
+// parts of the 32x32 pixel cells which the view shares with its parent.
+func (f *FrameBuffer) ClearDirty() {
+	f.dirty.set(f.Bounds().Add(f.dirtyOffset), false)
+}
@@ -0,0 +1,146 @@
+package gogl
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestDirtyRects(t *testing.T) {
+	f := NewFrameBuffer(100, 70)
+	if got, want := f.DirtyRects(), []image.Rectangle{f.Bounds()}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected new frame buffer to be dirty everywhere %v, got %v", want, got)
+	}
+
+	f.ClearDirty()
+	if f.IsDirty() {
+		t.Fatalf("Expected no dirty regions after ClearDirty, got %v", f.DirtyRects())
+	}
+
+	for _, tc := range []struct {
+		name string
+		draw func(f *FrameBuffer)
+		want []image.Rectangle
+	}{
+		{
+			name: "pixel",
+			draw: func(f *FrameBuffer) { f.SetPixel(40, 5, NewPixel(Red)) },
+			want: []image.Rectangle{image.Rect(32, 0, 64, 32)},
+		},
+		{
+			name: "clipped pixel",
+			draw: func(f *FrameBuffer) {
+				f.PushClip(image.Rect(0, 0, 10, 10))
+				f.SetPixel(40, 5, NewPixel(Red))
+				f.PopClip()
+			},
+			want: nil,
+		},
+		{
+			name: "column of cells",
+			draw: func(f *FrameBuffer) {
+				f.SetPixel(99, 0, NewPixel(Red))
+				f.SetPixel(99, 69, NewPixel(Red))
+				f.SetPixel(97, 40, NewPixel(Red))
+			},
+			want: []image.Rectangle{image.Rect(96, 0, 100, 70)},
+		},
+		{
+			name: "fill clip",
+			draw: func(f *FrameBuffer) {
+				f.PushClip(image.Rect(10, 40, 20, 50))
+				f.Fill(Blue)
+				f.PopClip()
+			},
+			want: []image.Rectangle{image.Rect(0, 32, 32, 64)},
+		},
+		{
+			name: "sub view",
+			draw: func(f *FrameBuffer) { f.SubView(30, 30, 10, 10).SetPixel(5, 5, NewPixel(Red)) },
+			want: []image.Rectangle{image.Rect(32, 32, 64, 64)},
+		},
+		{
+			name: "blit",
+			draw: func(f *FrameBuffer) { f.Blit(NewFrameBuffer(8, 8), image.Rect(0, 0, 8, 8), image.Pt(60, 2), nil) },
+			want: []image.Rectangle{image.Rect(32, 0, 96, 32)},
+		},
+		{
+			name: "mark",
+			draw: func(f *FrameBuffer) { f.MarkDirty(image.Rect(0, 0, 33, 33)) },
+			want: []image.Rectangle{image.Rect(0, 0, 64, 64)},
+		},
+	} {
+		f.ClearDirty()
+		tc.draw(f)
+		if got := f.DirtyRects(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected dirty regions %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSubViewDirtyRects(t *testing.T) {
+	f := NewFrameBuffer(100, 100)
+	view := f.SubView(20, 20, 50, 50)
+	f.ClearDirty()
+
+	f.SetPixel(40, 40, NewPixel(Red))
+	if got, want := view.DirtyRects(), []image.Rectangle{image.Rect(12, 12, 44, 44)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected view dirty regions %v, got %v", want, got)
+	}
+
+	view.ClearDirty()
+	if f.IsDirty() {
+		t.Errorf("Expected parent to be clean after clearing view, got %v", f.DirtyRects())
+	}
+}
+
+func TestParallelRendererDirtyRects(t *testing.T) {
+	queue := []Drawable{
+		NewCircle(20, Vec{50, 50}),
+		NewRect(10, 10, Vec{150, 10}),
+	}
+
+	want := NewFrameBuffer(200, 100)
+	want.ClearDirty()
+	SequentialRenderer{}.Render(want, queue)
+
+	got := NewFrameBuffer(200, 100)
+	got.ClearDirty()
+	ParallelRenderer{TileSize: 16}.Render(got, queue)
+
+	if !reflect.DeepEqual(got.DirtyRects(), want.DirtyRects()) {
+		t.Errorf("Expected dirty regions %v, got %v", want.DirtyRects(), got.DirtyRects())
+	}
+}
+
+func TestWindowPartialPresent(t *testing.T) {
+	backend := NewHeadlessBackend()
+	win, err := NewWindow(WindowCfg{Width: 100, Height: 100, Backend: backend, SkipUnchangedFrames: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Destroy()
+
+	win.SetBackground(Black)
+	win.Update()
+	if got, want := backend.DirtyRects(), []image.Rectangle{win.Framebuffer.Bounds()}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected first frame to be fully uploaded %v, got %v", want, got)
+	}
+
+	// Nothing changed, so nothing should be presented
+	win.Update()
+	if backend.FramesPresented() != 1 {
+		t.Errorf("Expected unchanged frame to be skipped, got %d frames", backend.FramesPresented())
+	}
+
+	win.Draw(NewRect(4, 4, Vec{70, 70}).SetStyle(Style{Colour: color.RGBA{255, 0, 0, 255}}))
+	win.Update()
+	if got, want := backend.DirtyRects(), []image.Rectangle{image.Rect(64, 64, 96, 96)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dirty regions %v, got %v", want, got)
+	}
+	frame := backend.Frame()
+	if frame.GetPixel(72, 72) != NewPixel(Red) || frame.GetPixel(10, 10) != NewPixel(Black) {
+		t.Error("Expected presented frame to match the frame buffer")
+	}
+}
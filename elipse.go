@@ -1,9 +1,16 @@
 package gogl
 
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
 type Ellipse struct {
 	Pos   Vec
 	w, h  float64
 	style Style
+	transformable
 }
 
 var _ Shape = (*Ellipse)(nil)
@@ -18,25 +25,107 @@ func NewEllipse(width, height float64, pos Vec) *Ellipse {
 }
 
 func (e *Ellipse) Draw(buf *FrameBuffer) {
+	if e.transformed {
+		e.transformedPath().Draw(buf)
+		return
+	}
+	if buf.isClipped(e.Bounds()) {
+		return
+	}
+
+	if e.style.AntiAlias {
+		e.drawAntiAliased(buf)
+		return
+	}
+
 	a := e.w / 2
 	b := e.h / 2
 
 	bbBoxPos := Vec{e.Pos.X - a, e.Pos.Y - b}
 	bbox := NewRect(e.w, e.h, bbBoxPos)
 
-	for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-		for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
+	br := newBrush(buf, e.style)
+	x0, x1 := clipSteps(bbox.Pos.X, bbox.Pos.X+bbox.w, buf.clip.Min.X, buf.clip.Max.X, truncPixel)
+	y0, y1 := clipSteps(bbox.Pos.Y, bbox.Pos.Y+bbox.h, buf.clip.Min.Y, buf.clip.Max.Y, truncPixel)
+	for i := x0; i < x1; i++ {
+		x := bbox.Pos.X + float64(i)
+		for j := y0; j < y1; j++ {
+			y := bbox.Pos.Y + float64(j)
 
 			p1 := (x - e.Pos.X) * (x - e.Pos.X) / (a * a)
 			p2 := (y - e.Pos.Y) * (y - e.Pos.Y) / (b * b)
 
 			if p1+p2 <= 1 {
-				buf.SetPixel(int(x), int(y), NewPixel(e.style.Colour))
+				br.plot(int(x), int(y))
+			}
+		}
+	}
+}
+
+// drawAntiAliased draws the ellipse with smooth edges, by scaling the alpha of each
+// pixel by how much of it the ellipse covers.
+func (e *Ellipse) drawAntiAliased(buf *FrameBuffer) {
+	a, b := e.w/2, e.h/2
+	if a <= 0 || b <= 0 {
+		return
+	}
+	area := e.Bounds().Intersect(buf.clip)
+
+	br := newBrush(buf, e.style)
+	for x := area.Min.X; x < area.Max.X; x++ {
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			dx, dy := float64(x)-e.Pos.X, float64(y)-e.Pos.Y
+
+			// Approximate the distance to the edge by dividing the ellipse's implicit
+			// function by the length of its gradient
+			g := math.Sqrt(dx*dx/(a*a) + dy*dy/(b*b))
+			grad := math.Hypot(dx/(a*a), dy/(b*b))
+			coverage := 1.0
+			if grad > 0 {
+				coverage = edgeCoverage((1 - g) * g / grad)
+			}
+			if coverage > 0 {
+				br.plotCoverage(x, y, coverage)
 			}
 		}
 	}
 }
 
+func (e *Ellipse) Bounds() image.Rectangle {
+	if e.transformed {
+		return e.transformedPath().Bounds()
+	}
+	return pixelBounds(e.Pos.X-e.w/2, e.Pos.Y-e.h/2, e.Pos.X+e.w/2, e.Pos.Y+e.h/2)
+}
+
+// SVG returns the ellipse as an SVG element.
+func (e *Ellipse) SVG() string {
+	return fmt.Sprintf(`<ellipse cx="%s" cy="%s" rx="%s" ry="%s"%s%s/>`,
+		svgNum(e.Pos.X), svgNum(e.Pos.Y), svgNum(e.w/2), svgNum(e.h/2), svgPaint(e.style, 0), e.svgTransform(e.Pos))
+}
+
+// SetTransform sets a transform which is applied about the centre of the ellipse.
+func (e *Ellipse) SetTransform(t Transform) *Ellipse {
+	e.setTransform(t)
+	return e
+}
+
+// viewed returns a copy of the ellipse with a view transform applied after its own,
+// and its opacity scaled.
+func (e *Ellipse) viewed(view Transform, opacity float64) Drawable {
+	v := *e
+	v.transformable = e.withView(view, e.Pos)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// transformedPath returns the ellipse as a path, with its transform applied.
+func (e *Ellipse) transformedPath() *Path {
+	return outlinePath(e.style.solid(), e.matrix(e.Pos), func(p *Path, _ float64) {
+		p.addEllipse(e.Pos, e.w/2, e.h/2)
+	})
+}
+
 func (e *Ellipse) GetPos() Vec {
 	return e.Pos
 }
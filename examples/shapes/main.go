@@ -34,7 +34,7 @@ func main() {
 	rectSolid := gogl.NewRect(
 		120, 90,
 		gogl.Vec{X: 50, Y: 50},
-	).SetStyle(gogl.Style{Colour: color.RGBA{0, 0, 255, 255}, Thickness: 0, Bloom: 30})
+	).SetStyle(gogl.Style{Colour: color.RGBA{0, 0, 255, 255}, Thickness: 0})
 	win.RegisterKeybind(gogl.KeyE, gogl.Instantaneous, func() { rectSolid.Move(gogl.Vec{X: 2, Y: 2}) })
 
 	rectOutline := gogl.NewRect(
@@ -43,8 +43,8 @@ func main() {
 	).SetStyle(gogl.Style{Colour: color.RGBA{255, 0, 0, 255}, Thickness: 2})
 
 	// Buttons can be constructed from shapes
-	styleHover := gogl.Style{Colour: color.RGBA{180, 180, 0, 255}, Thickness: 0, Bloom: 5}
-	stylePressed := gogl.Style{Colour: color.RGBA{255, 0, 0, 255}, Thickness: 0, Bloom: 10}
+	styleHover := gogl.Style{Colour: color.RGBA{180, 180, 0, 255}, Thickness: 0}
+	stylePressed := gogl.Style{Colour: color.RGBA{255, 0, 0, 255}, Thickness: 0}
 	styleUnpressed := gogl.Style{Colour: color.RGBA{80, 0, 0, 255}, Thickness: 30}
 	c := gogl.NewCircle(100, gogl.Vec{X: 300, Y: 100}).SetStyle(styleUnpressed)
 	circleButton := gogl.NewButton(c, "../../fonts/arial.ttf").
@@ -87,7 +87,7 @@ func main() {
 	curvedRect := gogl.NewCurvedRect(
 		120, 90, 20,
 		gogl.Vec{X: 50, Y: 200},
-	).SetStyle(gogl.Style{Colour: gogl.Orange, Thickness: 10, Bloom: 0})
+	).SetStyle(gogl.Style{Colour: gogl.Orange, Thickness: 10})
 
 	// Put shapes on the background layer to avoid interactions with other shapes
 	bgRect := gogl.NewRect(
@@ -124,6 +124,10 @@ func main() {
 	win.RegisterKeybind(gogl.KeyLeft, gogl.Instantaneous, func() { ellipse.SetWidth(ellipse.Width() + 1) })
 	win.RegisterKeybind(gogl.KeyRight, gogl.Instantaneous, func() { ellipse.SetWidth(ellipse.Width() - 1) })
 
+	// Drawables on a layer with a bloom effect glow. The layer is beneath the world layer,
+	// so the outline is drawn over the glowing rectangle
+	win.AddLayer("glow", 50).SetEffect(gogl.Bloom{Radius: 30})
+
 	// Register window-level keybinds
 	win.RegisterKeybind(gogl.KeyEscape, gogl.KeyPress, func() { win.Quit() })
 	win.RegisterKeybind(gogl.KeyLCtrl, gogl.KeyPress, func() { win.Quit() })
@@ -138,7 +142,6 @@ func main() {
 		// Draw shapes
 		win.Draw(
 			bgRect,
-			rectSolid,
 			rectOutline,
 			curvedRect,
 			triangle,
@@ -151,6 +154,8 @@ func main() {
 			ellipse,
 		)
 
+		win.DrawOn("glow", rectSolid)
+
 		// Lastly, the window must be updated
 		win.Update()
 
@@ -19,9 +19,14 @@ func main() {
 	defer win.Destroy()
 
 	snake := NewSnake(gogl.Vec{X: 400, Y: 100})
-	instruction := gogl.NewText("Use WASD to move", gogl.Vec{X: 10}, "../../fonts/arial.ttf")
+	instruction := gogl.NewText("Use WASD to move and H to hide", gogl.Vec{X: 10}, "../../fonts/arial.ttf")
+
+	// The scene is drawn on every frame, so the snake only needs to be added once
+	snakeNode := gogl.NewNode(snake)
+	win.SetScene(gogl.NewNode(nil).Add(snakeNode, gogl.NewNode(instruction)))
 
 	win.RegisterKeybind(gogl.KeyEscape, gogl.KeyPress, func() { win.Quit() })
+	win.RegisterKeybind(gogl.KeyH, gogl.KeyPress, func() { snakeNode.SetVisible(!snakeNode.Visible()) })
 
 	prevTime := time.Now()
 
@@ -48,11 +53,7 @@ func main() {
 			snake.Update(dt, win.Framebuffer)
 		}
 
-		win.SetBackground(color.RGBA{39, 45, 53, 0})
-
-		win.Draw(snake)
-		win.Draw(instruction)
-
+		win.SetBackground(color.RGBA{39, 45, 53, 255})
 		win.Update()
 	}
 }
@@ -1,6 +1,7 @@
 package gogl
 
 import (
+	"image"
 	"image/color"
 	"math"
 	"unsafe"
@@ -46,10 +47,20 @@ func pack(a, b, g, r uint8) Pixel {
 	return Pixel(uint32(a) | uint32(b)<<8 | uint32(g)<<16 | uint32(r)<<24)
 }
 
+// FrameBuffer is a two-dimensional grid of pixels which can be drawn onto.
 type FrameBuffer struct {
 	fb     []Pixel
 	width  int
 	height int
+	stride int // number of pixels between the start of each row
+
+	clip      image.Rectangle   // region that drawing is confined to
+	clipStack []image.Rectangle // previous clip regions, restored by PopClip
+
+	premultiplied bool // pixels are stored with premultiplied alpha
+
+	dirty       *dirtyTracker // regions which have been drawn onto, shared with views
+	dirtyOffset image.Point   // position of the frame buffer within the dirty tracker
 }
 
 // NewFrameBuffer constructs a new frame buffer with a particular width and height.
@@ -62,20 +73,72 @@ func NewFrameBuffer(width, height int) *FrameBuffer {
 		fb:     make([]Pixel, width*height),
 		width:  width,
 		height: height,
+		stride: width,
+		clip:   image.Rect(0, 0, width, height),
+		dirty:  newDirtyTracker(width, height),
 	}
 }
 
-// GetPixel returns a copy of the pixel at the specified coordinates.
+// SubView returns a frame buffer which shares memory with a rectangular region of
+// this frame buffer. The view uses its own coordinates, with the origin at the top-left
+// of the region, and inherits the part of the current clip region that overlaps it.
+// The region must lie within the bounds of the frame buffer.
+func (f *FrameBuffer) SubView(x, y, width, height int) *FrameBuffer {
+	region := image.Rect(x, y, x+width, y+height)
+	if width < 1 || height < 1 || !region.In(f.Bounds()) {
+		panic("SubView out of bounds")
+	}
+
+	start := x + f.stride*y
+	end := start + f.stride*(height-1) + width
+
+	return &FrameBuffer{
+		fb:     f.fb[start:end:end],
+		width:  width,
+		height: height,
+		stride: f.stride,
+		clip:   f.clip.Intersect(region).Sub(region.Min),
+
+		premultiplied: f.premultiplied,
+
+		dirty:       f.dirty,
+		dirtyOffset: f.dirtyOffset.Add(region.Min),
+	}
+}
+
+// isContiguous returns true if the rows of the frame buffer are adjacent in memory.
+func (f *FrameBuffer) isContiguous() bool {
+	return f.stride == f.width
+}
+
+// row returns the pixels in a row of the frame buffer.
+func (f *FrameBuffer) row(y int) []Pixel {
+	start := f.stride * y
+	return f.fb[start : start+f.width]
+}
+
+// clone returns a deep copy of the frame buffer.
+func (f *FrameBuffer) clone() *FrameBuffer {
+	c := NewFrameBuffer(f.width, f.height)
+	c.premultiplied = f.premultiplied
+	for y := range f.height {
+		copy(c.row(y), f.row(y))
+	}
+	return c
+}
+
+// GetPixel returns a copy of the pixel at the specified coordinates, with straight
+// alpha.
 func (f *FrameBuffer) GetPixel(x, y int) Pixel {
 	if y > f.Height()-1 || y < 0 || x > f.Width()-1 || x < 0 {
 		panic("GetPixel out of bounds")
 	}
 
-	return f.getPixel(x, y)
+	return f.toStraight(f.getPixel(x, y))
 }
 
 func (f *FrameBuffer) getPixel(x, y int) Pixel {
-	targetPix := x + f.width*y
+	targetPix := x + f.stride*y
 	return f.fb[targetPix]
 }
 
@@ -83,8 +146,20 @@ func (f *FrameBuffer) getPixel(x, y int) Pixel {
 type BlendFunc func(src, dst Pixel) Pixel
 
 // SetPixelFunc sets a pixel in the frame buffer using the specified blend function.
+// If the requested pixel is outside of the clip region, nothing happens. The pixel and
+// blend function use straight alpha, even if the frame buffer is premultiplied.
 func (f *FrameBuffer) SetPixelFunc(x, y int, p Pixel, blend BlendFunc) {
-	if y > f.Height()-1 || y < 0 || x > f.Width()-1 || x < 0 {
+	if f.premultiplied {
+		p, blend = p.Premultiply(), PremultipliedBlend(blend)
+	}
+	f.blendPixel(x, y, p, blend)
+}
+
+// blendPixel blends a pixel into the frame buffer, where the pixel and blend function
+// are already in the frame buffer's alpha format. If the requested pixel is outside of
+// the clip region, nothing happens.
+func (f *FrameBuffer) blendPixel(x, y int, p Pixel, blend BlendFunc) {
+	if !f.inClip(x, y) {
 		return
 	}
 
@@ -94,28 +169,40 @@ func (f *FrameBuffer) SetPixelFunc(x, y int, p Pixel, blend BlendFunc) {
 }
 
 func (f *FrameBuffer) setPixel(x, y int, p Pixel) {
-	targetPix := x + f.width*y
+	targetPix := x + f.stride*y
 	f.fb[targetPix] = p
+	f.dirty.mark(x+f.dirtyOffset.X, y+f.dirtyOffset.Y)
 }
 
 // SetPixel sets a pixel in the frame buffer. If the requested pixel is out of
-// bounds, nothing happens. The default alpha blending technique is used. To use
-// other blending methods, see SetPixelFunc.
+// bounds or outside of the clip region, nothing happens. The default alpha blending
+// technique is used. To use other blending methods, see SetPixelFunc.
 func (f *FrameBuffer) SetPixel(x, y int, p Pixel) {
 	f.SetPixelFunc(x, y, p, AlphaBlend)
 }
 
-// Clear sets every pixel in the frame buffer to zero.
+// Clear sets every pixel in the clip region of the frame buffer to zero.
 func (f *FrameBuffer) Clear() {
 	f.Fill(color.RGBA{0, 0, 0, 0})
 }
 
-// Fill sets every pixel in the frame buffer to the provided colour.
+// Fill sets every pixel in the clip region of the frame buffer to the provided colour.
 func (f *FrameBuffer) Fill(c color.Color) {
-	p := NewPixel(c)
+	p := f.toNative(NewPixel(c))
+	f.MarkDirty(f.clip)
 
-	for i := range f.fb {
-		f.fb[i] = p
+	if f.clip == f.Bounds() && f.isContiguous() {
+		for i := range f.fb {
+			f.fb[i] = p
+		}
+		return
+	}
+
+	for y := f.clip.Min.Y; y < f.clip.Max.Y; y++ {
+		row := f.row(y)[f.clip.Min.X:f.clip.Max.X]
+		for i := range row {
+			row[i] = p
+		}
 	}
 }
 
@@ -129,9 +216,39 @@ func (f *FrameBuffer) Height() int {
 	return f.height
 }
 
-// Bytes returns the frame buffer as a one-dimensional slice of bytes.
+// Bytes returns the frame buffer as a one-dimensional slice of bytes, with rows packed
+// one after another. For frame buffers that own their memory, the slice aliases the
+// pixel data. For views whose rows are not adjacent in memory, the rows are copied
+// into a new slice. Premultiplied frame buffers are always copied, as the pixels are
+// converted to straight alpha.
 func (f *FrameBuffer) Bytes() []byte {
-	return unsafe.Slice((*byte)(unsafe.Pointer(&f.fb[0])), len(f.fb)*pxLen)
+	if f.isContiguous() && !f.premultiplied {
+		return unsafe.Slice((*byte)(unsafe.Pointer(&f.fb[0])), f.width*f.height*pxLen)
+	}
+
+	packed := make([]Pixel, 0, f.width*f.height)
+	for y := range f.height {
+		packed = append(packed, f.row(y)...)
+	}
+	if f.premultiplied {
+		for i, p := range packed {
+			packed[i] = p.Unpremultiply()
+		}
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&packed[0])), len(packed)*pxLen)
+}
+
+// regionBytes returns the pixels in a rectangular region of the frame buffer as bytes,
+// along with the number of bytes between the start of each row. The bytes alias the
+// pixel data, unless the frame buffer is premultiplied.
+func (f *FrameBuffer) regionBytes(r image.Rectangle) ([]byte, int) {
+	if f.premultiplied {
+		return f.SubView(r.Min.X, r.Min.Y, r.Dx(), r.Dy()).Bytes(), r.Dx() * pxLen
+	}
+
+	start := r.Min.X + f.stride*r.Min.Y
+	end := start + f.stride*(r.Dy()-1) + r.Dx()
+	return unsafe.Slice((*byte)(unsafe.Pointer(&f.fb[start])), (end-start)*pxLen), f.stride * pxLen
 }
 
 // WithinFrame returns true if the given point lies within the boundary of the frame
@@ -144,40 +261,10 @@ func (f *FrameBuffer) WithinFrame(point Vec, padding float64) bool {
 	return point.X-1 < w-padding && point.Y-1 < h-padding && point.X > padding && point.Y > padding
 }
 
-// DrawLine draws a single pixel line using Bresenham's line drawing algorithm.
+// DrawLine draws a white single pixel line using Bresenham's line drawing algorithm.
+// Use Line to draw lines with other styles.
 func DrawLine(v1, v2 Vec, buf *FrameBuffer) {
-	dx := math.Abs(v2.X - v1.X)
-	sx := 1
-	if v1.X > v2.X {
-		sx = -1
-	}
-
-	dy := -math.Abs(v2.Y - v1.Y)
-	sy := 1
-	if v1.Y > v2.Y {
-		sy = -1
-	}
-
-	err := dx + dy
-	x1, y1 := int(math.Round(v1.X)), int(math.Round(v1.Y))
-
-	for {
-		buf.SetPixel(x1, y1, NewPixel(color.White))
-		x2, y2 := int(math.Round(v2.X)), int(math.Round(v2.Y))
-		if x1 == x2 && y1 == y2 {
-			break
-		}
-
-		e2 := 2 * err
-		if e2 >= dy {
-			err += dy
-			x1 += sx
-		}
-		if e2 <= dx {
-			err += dx
-			y1 += sy
-		}
-	}
+	NewLine(v1, v2).SetStyle(Style{Colour: color.White}).Draw(buf)
 }
 
 // AlphaBlend blends a source pixel over a destination pixel using the Porter-Duff
@@ -1,6 +1,8 @@
 package gogl
 
 import (
+	"bytes"
+	"image/color"
 	"testing"
 )
 
@@ -35,3 +37,198 @@ func BenchmarkAlphaBlend(b *testing.B) {
 		_ = AlphaBlend(src, dst)
 	}
 }
+
+func BenchmarkAlphaBlendPremultiplied(b *testing.B) {
+	src := pack(100, 80, 70, 60).Premultiply()
+	dst := pack(200, 160, 140, 60).Premultiply()
+
+	for n := 0; n < b.N; n++ {
+		_ = AlphaBlendPremultiplied(src, dst)
+	}
+}
+
+func BenchmarkSetPixelFunc(b *testing.B) {
+	for name, f := range map[string]*FrameBuffer{
+		"straight":      NewFrameBuffer(64, 64),
+		"premultiplied": NewPremultipliedFrameBuffer(64, 64),
+	} {
+		b.Run(name, func(b *testing.B) {
+			p := pack(100, 80, 70, 60)
+			for n := 0; n < b.N; n++ {
+				f.SetPixelFunc(n&63, n>>6&63, p, AlphaBlend)
+			}
+		})
+	}
+}
+
+func BenchmarkDrawLine(b *testing.B) {
+	f := NewFrameBuffer(1920, 1080)
+	for n := 0; n < b.N; n++ {
+		DrawLine(Vec{0, 0}, Vec{1919, 1079}, f)
+	}
+}
+
+// blendModes maps the names of blend functions to the functions.
+var blendModes = []struct {
+	name  string
+	blend BlendFunc
+}{
+	{"Clear", ClearBlend},
+	{"Src", SrcBlend},
+	{"Dst", DstBlend},
+	{"DstOver", DstOverBlend},
+	{"SrcIn", SrcInBlend},
+	{"DstIn", DstInBlend},
+	{"SrcOut", SrcOutBlend},
+	{"DstOut", DstOutBlend},
+	{"SrcAtop", SrcAtopBlend},
+	{"DstAtop", DstAtopBlend},
+	{"Xor", XorBlend},
+	{"Multiply", MultiplyBlend},
+	{"Screen", ScreenBlend},
+	{"Overlay", OverlayBlend},
+	{"Darken", DarkenBlend},
+	{"Lighten", LightenBlend},
+	{"ColourDodge", ColourDodgeBlend},
+	{"ColourBurn", ColourBurnBlend},
+	{"HardLight", HardLightBlend},
+	{"SoftLight", SoftLightBlend},
+	{"Difference", DifferenceBlend},
+	{"Exclusion", ExclusionBlend},
+}
+
+func TestBlendModes(t *testing.T) {
+	// Expected values are calculated from the formulas in the W3C Compositing and
+	// Blending specification, using floating point arithmetic.
+	translucentSrc := NewPixel(color.RGBA{200, 40, 60, 160})
+	translucentDst := NewPixel(color.RGBA{20, 120, 220, 200})
+	opaqueSrc := NewPixel(color.RGBA{200, 40, 60, 255})
+	opaqueDst := NewPixel(color.RGBA{20, 120, 220, 255})
+
+	type tc struct {
+		src, dst Pixel
+		expected color.RGBA
+	}
+	expected := map[string][]tc{
+		"Clear":       {{translucentSrc, translucentDst, color.RGBA{0, 0, 0, 0}}},
+		"Src":         {{translucentSrc, translucentDst, color.RGBA{200, 40, 60, 160}}},
+		"Dst":         {{translucentSrc, translucentDst, color.RGBA{20, 120, 220, 200}}},
+		"DstOver":     {{translucentSrc, translucentDst, color.RGBA{46, 108, 196, 235}}},
+		"SrcIn":       {{translucentSrc, translucentDst, color.RGBA{200, 40, 60, 125}}},
+		"DstIn":       {{translucentSrc, translucentDst, color.RGBA{20, 120, 220, 125}}},
+		"SrcOut":      {{translucentSrc, translucentDst, color.RGBA{200, 40, 60, 35}}},
+		"DstOut":      {{translucentSrc, translucentDst, color.RGBA{20, 120, 220, 75}}},
+		"SrcAtop":     {{translucentSrc, translucentDst, color.RGBA{133, 70, 120, 200}}},
+		"DstAtop":     {{translucentSrc, translucentDst, color.RGBA{59, 103, 185, 160}}},
+		"Xor":         {{translucentSrc, translucentDst, color.RGBA{77, 95, 169, 109}}},
+		"Multiply":    {{translucentSrc, translucentDst, color.RGBA{44, 54, 106, 235}}, {opaqueSrc, opaqueDst, color.RGBA{16, 19, 52, 255}}},
+		"Screen":      {{translucentSrc, translucentDst, color.RGBA{145, 120, 201, 235}}, {opaqueSrc, opaqueDst, color.RGBA{204, 141, 228, 255}}},
+		"Overlay":     {{translucentSrc, translucentDst, color.RGBA{53, 64, 187, 235}}, {opaqueSrc, opaqueDst, color.RGBA{31, 38, 201, 255}}},
+		"Darken":      {{translucentSrc, translucentDst, color.RGBA{46, 65, 111, 235}}, {opaqueSrc, opaqueDst, color.RGBA{20, 40, 60, 255}}},
+		"Lighten":     {{translucentSrc, translucentDst, color.RGBA{143, 108, 196, 235}}, {opaqueSrc, opaqueDst, color.RGBA{200, 120, 220, 255}}},
+		"ColourDodge": {{translucentSrc, translucentDst, color.RGBA{85, 120, 215, 235}}, {opaqueSrc, opaqueDst, color.RGBA{93, 142, 255, 255}}},
+		"ColourBurn":  {{translucentSrc, translucentDst, color.RGBA{36, 44, 136, 235}}, {opaqueSrc, opaqueDst, color.RGBA{0, 0, 106, 255}}},
+		"HardLight":   {{translucentSrc, translucentDst, color.RGBA{118, 64, 134, 235}}, {opaqueSrc, opaqueDst, color.RGBA{154, 38, 104, 255}}},
+		"SoftLight":   {{translucentSrc, translucentDst, color.RGBA{60, 85, 188, 235}}, {opaqueSrc, opaqueDst, color.RGBA{45, 76, 204, 255}}},
+		"Difference":  {{translucentSrc, translucentDst, color.RGBA{132, 87, 164, 235}}, {opaqueSrc, opaqueDst, color.RGBA{180, 80, 160, 255}}},
+		"Exclusion":   {{translucentSrc, translucentDst, color.RGBA{137, 109, 173, 235}}, {opaqueSrc, opaqueDst, color.RGBA{189, 122, 176, 255}}},
+	}
+
+	// Integer arithmetic may round differently to the reference values
+	const tolerance = 1
+	near := func(a, b uint8) bool {
+		return max(a, b)-min(a, b) <= tolerance
+	}
+
+	for _, mode := range blendModes {
+		cases, ok := expected[mode.name]
+		if !ok {
+			t.Errorf("%s: no test cases", mode.name)
+		}
+		for _, c := range cases {
+			got := mode.blend(c.src, c.dst)
+			if !near(got.R(), c.expected.R) || !near(got.G(), c.expected.G) ||
+				!near(got.B(), c.expected.B) || !near(got.A(), c.expected.A) {
+				t.Errorf("%s: expected %v, got {%d %d %d %d}", mode.name, c.expected,
+					got.R(), got.G(), got.B(), got.A())
+			}
+		}
+
+		// Blending fully transparent pixels should never produce colour
+		if got := mode.blend(0, 0); got != 0 {
+			t.Errorf("%s: expected transparent result, got %#x", mode.name, got)
+		}
+	}
+}
+
+func BenchmarkBlendModes(b *testing.B) {
+	src := pack(100, 80, 70, 60)
+	dst := pack(200, 160, 140, 60)
+
+	for _, mode := range blendModes {
+		b.Run(mode.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = mode.blend(src, dst)
+			}
+		})
+		premultiplied := PremultipliedBlend(mode.blend)
+		b.Run(mode.name+"Premultiplied", func(b *testing.B) {
+			src, dst := src.Premultiply(), dst.Premultiply()
+			for n := 0; n < b.N; n++ {
+				_ = premultiplied(src, dst)
+			}
+		})
+	}
+}
+
+func TestSubView(t *testing.T) {
+	parent := NewFrameBuffer(10, 8)
+	view := parent.SubView(2, 3, 4, 3)
+
+	if view.Width() != 4 || view.Height() != 3 {
+		t.Fatalf("Expected view size 4x3, got %dx%d", view.Width(), view.Height())
+	}
+
+	// Drawing into the view should write to the parent's memory, in local coordinates
+	view.Fill(Red)
+	view.SetPixel(0, 0, NewPixel(Blue))
+	view.SetPixel(4, 0, NewPixel(Blue)) // out of the view's bounds
+	for y := range parent.Height() {
+		for x := range parent.Width() {
+			var want Pixel
+			switch {
+			case x == 2 && y == 3:
+				want = NewPixel(Blue)
+			case x >= 2 && x < 6 && y >= 3 && y < 6:
+				want = NewPixel(Red)
+			}
+			if got := parent.GetPixel(x, y); got != want {
+				t.Errorf("Pixel (%d, %d): expected %#x, got %#x", x, y, want, got)
+			}
+		}
+	}
+
+	// Bytes should pack the non-contiguous rows of the view
+	if got, want := len(view.Bytes()), 4*3*pxLen; got != want {
+		t.Fatalf("Expected %d bytes, got %d", want, got)
+	}
+	if !bytes.Equal(view.Bytes(), view.clone().Bytes()) {
+		t.Error("Expected bytes of view to match bytes of a contiguous copy")
+	}
+
+	// Nested views should be relative to their parent view
+	nested := view.SubView(1, 1, 2, 2)
+	nested.SetPixel(1, 1, NewPixel(Lime))
+	if got := parent.GetPixel(4, 5); got != NewPixel(Lime) {
+		t.Errorf("Expected nested view to write to parent, got %#x", got)
+	}
+}
+
+func TestSubViewOutOfBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected SubView to panic")
+		}
+	}()
+	NewFrameBuffer(10, 10).SubView(5, 5, 6, 1)
+}
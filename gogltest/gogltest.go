@@ -0,0 +1,145 @@
+// Package gogltest provides golden image snapshot testing for gogl drawables.
+//
+// Drawables are rendered into a frame buffer and compared against PNG files stored in
+// the testdata directory of the package under test. Run the tests with the -update flag
+// to regenerate the golden images:
+//
+//	go test . -update
+package gogltest
+
+import (
+	"errors"
+	"flag"
+	"image/color"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/z-riley/gogl"
+)
+
+var update = flag.Bool("update", false, "regenerate golden images instead of comparing against them")
+
+// Options configures how a drawable is rendered and compared against its golden image.
+type Options struct {
+	// Width, Height specifies the dimensions of the frame buffer that the drawable is
+	// rendered into, in pixels.
+	Width, Height int
+	// Background is the colour the frame buffer is filled with before drawing.
+	// Transparent if nil.
+	Background color.Color
+	// Tolerance is the maximum difference allowed in any colour channel before a pixel
+	// is considered to differ from the golden image.
+	Tolerance uint8
+	// MaxDiffPixels is the number of differing pixels allowed before the test fails.
+	MaxDiffPixels int
+	// Dir is the directory containing the golden images. Defaults to "testdata".
+	Dir string
+}
+
+// Render draws the drawable into a new frame buffer of the given size, filled with the
+// background colour.
+func Render(d gogl.Drawable, width, height int, background color.Color) *gogl.FrameBuffer {
+	buf := gogl.NewFrameBuffer(width, height)
+	if background != nil {
+		buf.Fill(background)
+	}
+	d.Draw(buf)
+	return buf
+}
+
+// AssertDrawable renders the drawable and compares it against the golden image with
+// the given name. See AssertGolden.
+func AssertDrawable(t testing.TB, name string, d gogl.Drawable, opts Options) {
+	t.Helper()
+	AssertGolden(t, name, Render(d, opts.Width, opts.Height, opts.Background), opts)
+}
+
+// AssertGolden compares the frame buffer against the golden image <name>.png. If they
+// differ by more than the tolerances in opts, the test fails and a diff image is written
+// to <name>.diff.png. If the -update flag is set, the golden image is overwritten instead.
+func AssertGolden(t testing.TB, name string, got *gogl.FrameBuffer, opts Options) {
+	t.Helper()
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = "testdata"
+	}
+	goldenPath := filepath.Join(dir, name+".png")
+	diffPath := filepath.Join(dir, name+".diff.png")
+
+	if *update {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := got.Save(goldenPath); err != nil {
+			t.Fatalf("failed to write golden image: %v", err)
+		}
+		_ = os.Remove(diffPath)
+		return
+	}
+
+	want, err := gogl.LoadFrameBuffer(goldenPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden image %s does not exist; run the test with -update to create it", goldenPath)
+	} else if err != nil {
+		t.Fatalf("failed to load golden image: %v", err)
+	}
+
+	if got.Width() != want.Width() || got.Height() != want.Height() {
+		t.Fatalf("%s: size mismatch: got %dx%d, want %dx%d",
+			name, got.Width(), got.Height(), want.Width(), want.Height())
+	}
+
+	diffs, diff := Compare(got, want, opts.Tolerance)
+	if diffs <= opts.MaxDiffPixels {
+		_ = os.Remove(diffPath)
+		return
+	}
+
+	if err := diff.Save(diffPath); err != nil {
+		t.Errorf("failed to write diff image: %v", err)
+	}
+	t.Errorf("%s: %d pixels differ from the golden image (%d allowed); see %s",
+		name, diffs, opts.MaxDiffPixels, diffPath)
+}
+
+// Compare compares two frame buffers of equal size pixel by pixel. It returns the
+// number of pixels with any channel differing by more than the tolerance, and a diff
+// image where those pixels are red and matching pixels are a faded green.
+func Compare(got, want *gogl.FrameBuffer, tolerance uint8) (int, *gogl.FrameBuffer) {
+	if got.Width() != want.Width() || got.Height() != want.Height() {
+		panic("Compare - frame buffers must be the same size")
+	}
+
+	diffs := 0
+	diff := gogl.NewFrameBuffer(got.Width(), got.Height())
+	for y := range got.Height() {
+		for x := range got.Width() {
+			g, w := got.GetPixel(x, y), want.GetPixel(x, y)
+			if channelDiff(g.R(), w.R()) > tolerance || channelDiff(g.G(), w.G()) > tolerance ||
+				channelDiff(g.B(), w.B()) > tolerance || channelDiff(g.A(), w.A()) > tolerance {
+				diffs++
+				diff.Set(x, y, gogl.Red)
+				continue
+			}
+
+			// Show matching pixels in green with brightness from the expected image, so
+			// the differences can be seen in context
+			lum := (uint16(w.R()) + uint16(w.G()) + uint16(w.B())) / 3
+			shade := uint8(uint16(w.A()) * (64 + lum*3/4) / 255)
+			diff.Set(x, y, color.RGBA{0, shade, 0, 255})
+		}
+	}
+
+	return diffs, diff
+}
+
+// channelDiff returns the absolute difference between two channel values.
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,285 @@
+package gogl_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/z-riley/gogl"
+	"github.com/z-riley/gogl/gogltest"
+)
+
+// goldenOpts are the rendering options shared by the built-in shape golden tests.
+var goldenOpts = gogltest.Options{
+	Width:      64,
+	Height:     64,
+	Background: gogl.Black,
+}
+
+func TestShapeGoldens(t *testing.T) {
+	solid := gogl.Style{Colour: gogl.Orange}
+	outline := gogl.Style{Colour: gogl.Cyan, Thickness: 3}
+	bloom := gogl.Style{Colour: gogl.Magenta}
+	glow := gogl.Bloom{Radius: 8}
+	translucent := gogl.Style{Colour: color.RGBA{0, 255, 0, 128}}
+	smooth := gogl.Style{Colour: gogl.Orange, AntiAlias: true}
+	smoothOutline := gogl.Style{Colour: gogl.Cyan, Thickness: 3, AntiAlias: true}
+	stroke := gogl.NewPath().
+		MoveTo(gogl.Vec{X: 8, Y: 52}).
+		QuadTo(gogl.Vec{X: 32, Y: -8}, gogl.Vec{X: 56, Y: 52}).
+		LineTo(gogl.Vec{X: 20, Y: 40}).
+		Close().
+		SetStyle(gogl.Style{Colour: gogl.Green, Thickness: 4, AntiAlias: true}).
+		SetJoin(gogl.JoinRound)
+
+	for _, tc := range []struct {
+		name string
+		d    gogl.Drawable
+	}{
+		{"rect_solid", gogl.NewRect(40, 30, gogl.Vec{X: 12, Y: 17}).SetStyle(solid)},
+		{"rect_outline", gogl.NewRect(40, 30, gogl.Vec{X: 12, Y: 17}).SetStyle(outline)},
+		{"rect_bloom", bloomed(gogl.NewRect(30, 20, gogl.Vec{X: 17, Y: 22}).SetStyle(bloom), glow)},
+		{"curved_rect_solid", gogl.NewCurvedRect(44, 34, 10, gogl.Vec{X: 10, Y: 15}).SetStyle(solid)},
+		{"curved_rect_outline", gogl.NewCurvedRect(44, 34, 10, gogl.Vec{X: 10, Y: 15}).SetStyle(outline)},
+		{"curved_rect_bloom", bloomed(gogl.NewCurvedRect(34, 24, 8, gogl.Vec{X: 15, Y: 20}).SetStyle(bloom), glow)},
+		{"circle_solid", gogl.NewCircle(40, gogl.Vec{X: 32, Y: 32}).SetStyle(solid)},
+		{"circle_outline", gogl.NewCircle(40, gogl.Vec{X: 32, Y: 32}).SetStyle(outline)},
+		{"circle_bloom", bloomed(gogl.NewCircle(30, gogl.Vec{X: 32, Y: 32}).SetStyle(bloom), glow)},
+		{"circle_translucent", gogl.NewCircle(40, gogl.Vec{X: 32, Y: 32}).SetStyle(translucent)},
+		{"ellipse_solid", gogl.NewEllipse(50, 30, gogl.Vec{X: 32, Y: 32}).SetStyle(solid)},
+		{"triangle_solid", gogl.NewTriangle(
+			gogl.Vec{X: 8, Y: 56}, gogl.Vec{X: 32, Y: 8}, gogl.Vec{X: 56, Y: 48},
+		).SetStyle(solid)},
+		{"polygon_solid", gogl.NewPolygon([]gogl.Vec{
+			{X: 8, Y: 8}, {X: 56, Y: 12}, {X: 40, Y: 32}, {X: 56, Y: 56}, {X: 12, Y: 48},
+		}).SetStyle(solid)},
+		{"curved_rect_aa", gogl.NewCurvedRect(44, 34, 10, gogl.Vec{X: 10, Y: 15}).SetStyle(smooth)},
+		{"curved_rect_outline_aa", gogl.NewCurvedRect(44, 34, 10, gogl.Vec{X: 10, Y: 15}).SetStyle(smoothOutline)},
+		{"circle_aa", gogl.NewCircle(40, gogl.Vec{X: 32, Y: 32}).SetStyle(smooth)},
+		{"circle_outline_aa", gogl.NewCircle(40, gogl.Vec{X: 32, Y: 32}).SetStyle(smoothOutline)},
+		{"ellipse_aa", gogl.NewEllipse(50, 30, gogl.Vec{X: 32, Y: 32}).SetStyle(smooth)},
+		{"triangle_aa", gogl.NewTriangle(
+			gogl.Vec{X: 8, Y: 56}, gogl.Vec{X: 32, Y: 8}, gogl.Vec{X: 56, Y: 48},
+		).SetStyle(smooth)},
+		{"polygon_aa", gogl.NewPolygon([]gogl.Vec{
+			{X: 8, Y: 8}, {X: 56, Y: 12}, {X: 40, Y: 32}, {X: 56, Y: 56}, {X: 12, Y: 48},
+		}).SetStyle(smooth)},
+		{"line", drawableFunc(func(buf *gogl.FrameBuffer) {
+			gogl.DrawLine(gogl.Vec{X: 4, Y: 60}, gogl.Vec{X: 60, Y: 10}, buf)
+		})},
+		{"text", gogl.NewText("gogl\ntext", gogl.Vec{X: 4, Y: 2}, "fonts/luxisr.ttf").SetColour(gogl.White)},
+		{"line_caps", drawableFunc(func(buf *gogl.FrameBuffer) {
+			thick := gogl.Style{Colour: gogl.Orange, Thickness: 8, AntiAlias: true}
+			for i, c := range []gogl.LineCap{gogl.CapButt, gogl.CapRound, gogl.CapSquare} {
+				y := float64(14 + 18*i)
+				gogl.NewLine(gogl.Vec{X: 14, Y: y}, gogl.Vec{X: 50, Y: y}).SetStyle(thick).SetCap(c).Draw(buf)
+			}
+		})},
+		{"line_aa", drawableFunc(func(buf *gogl.FrameBuffer) {
+			gogl.NewLine(gogl.Vec{X: 4, Y: 60}, gogl.Vec{X: 60, Y: 10}).
+				SetStyle(gogl.Style{Colour: gogl.White, AntiAlias: true}).Draw(buf)
+			gogl.NewLine(gogl.Vec{X: 4, Y: 30}, gogl.Vec{X: 40, Y: 62}).
+				SetStyle(gogl.Style{Colour: gogl.Cyan, Thickness: 4, AntiAlias: true}).Draw(buf)
+		})},
+		{"line_bloom", bloomed(gogl.NewLine(gogl.Vec{X: 14, Y: 50}, gogl.Vec{X: 50, Y: 14}).
+			SetStyle(gogl.Style{Colour: gogl.Magenta, Thickness: 4}).SetCap(gogl.CapRound), glow)},
+		{"bloom_threshold", bloomed(drawableFunc(func(buf *gogl.FrameBuffer) {
+			// Only the bright shapes glow
+			gogl.NewEllipse(40, 16, gogl.Vec{X: 24, Y: 14}).SetStyle(gogl.Style{Colour: gogl.Yellow}).Draw(buf)
+			gogl.NewTriangle(gogl.Vec{X: 6, Y: 58}, gogl.Vec{X: 20, Y: 30}, gogl.Vec{X: 30, Y: 58}).
+				SetStyle(gogl.Style{Colour: color.RGBA{60, 60, 120, 255}}).Draw(buf)
+			gogl.NewText("hi", gogl.Vec{X: 36, Y: 30}, "fonts/luxisr.ttf").SetColour(gogl.Cyan).Draw(buf)
+		}), gogl.Bloom{Radius: 6, Intensity: 1.5, Threshold: 0.8})},
+		{"polyline_joins", drawableFunc(func(buf *gogl.FrameBuffer) {
+			for i, j := range []gogl.LineJoin{gogl.JoinMiter, gogl.JoinRound, gogl.JoinBevel} {
+				x := float64(20 * i)
+				gogl.NewPolyline([]gogl.Vec{{X: x + 6, Y: 56}, {X: x + 12, Y: 8}, {X: x + 18, Y: 56}}).
+					SetStyle(gogl.Style{Colour: gogl.Orange, Thickness: 5, AntiAlias: true}).
+					SetJoin(j).
+					Draw(buf)
+			}
+		})},
+		{"polyline_translucent", gogl.NewPolyline([]gogl.Vec{
+			{X: 8, Y: 8}, {X: 56, Y: 56}, {X: 56, Y: 8}, {X: 8, Y: 56}, {X: 32, Y: 4},
+		}).SetStyle(gogl.Style{Colour: color.RGBA{0, 255, 0, 128}, Thickness: 6}).SetJoin(gogl.JoinRound)},
+		{"path_curves", gogl.NewPath().
+			MoveTo(gogl.Vec{X: 32, Y: 56}).
+			CubicTo(gogl.Vec{X: 4, Y: 36}, gogl.Vec{X: 8, Y: 8}, gogl.Vec{X: 32, Y: 20}).
+			CubicTo(gogl.Vec{X: 56, Y: 8}, gogl.Vec{X: 60, Y: 36}, gogl.Vec{X: 32, Y: 56}).
+			Close().
+			SetStyle(gogl.Style{Colour: gogl.Red, AntiAlias: true})},
+		{"path_fill_rules", drawableFunc(func(buf *gogl.FrameBuffer) {
+			for i, rule := range []gogl.FillRule{gogl.FillNonZero, gogl.FillEvenOdd} {
+				star := gogl.NewPath().SetFillRule(rule).SetStyle(gogl.Style{Colour: gogl.Yellow})
+				for j := range 5 {
+					angle := float64(j) * 4 * math.Pi / 5
+					pt := gogl.Vec{X: 16 + 32*float64(i) + 14*math.Sin(angle), Y: 32 - 14*math.Cos(angle)}
+					if j == 0 {
+						star.MoveTo(pt)
+					} else {
+						star.LineTo(pt)
+					}
+				}
+				star.Close().Draw(buf)
+			}
+		})},
+		{"path_arcs", drawableFunc(func(buf *gogl.FrameBuffer) {
+			// A ring made from two circles, with the hole cut out by the even-odd rule
+			gogl.NewPath().
+				MoveTo(gogl.Vec{X: 4, Y: 20}).
+				ArcTo(16, 16, 0, false, true, gogl.Vec{X: 36, Y: 20}).
+				ArcTo(16, 16, 0, false, true, gogl.Vec{X: 4, Y: 20}).
+				Close().
+				MoveTo(gogl.Vec{X: 12, Y: 20}).
+				ArcTo(8, 8, 0, false, true, gogl.Vec{X: 28, Y: 20}).
+				ArcTo(8, 8, 0, false, true, gogl.Vec{X: 12, Y: 20}).
+				Close().
+				SetFillRule(gogl.FillEvenOdd).
+				SetStyle(gogl.Style{Colour: gogl.Cyan, AntiAlias: true}).
+				Draw(buf)
+			gogl.NewPath().
+				MoveTo(gogl.Vec{X: 24, Y: 56}).
+				ArcTo(16, 8, math.Pi/6, true, true, gogl.Vec{X: 52, Y: 44}).
+				SetStyle(gogl.Style{Colour: gogl.Orange, Thickness: 3, AntiAlias: true}).
+				SetCap(gogl.CapRound).
+				Draw(buf)
+		})},
+		{"path_stroke", stroke},
+		{"path_stroke_bloom", bloomed(stroke, gogl.Bloom{Radius: 4})},
+		{"svg_icon", drawableFunc(func(buf *gogl.FrameBuffer) {
+			icon, err := gogl.LoadSVG("testdata/icon.svg")
+			if err != nil {
+				panic(err)
+			}
+			icon.Draw(buf)
+			icon.SetPos(gogl.Vec{X: 36, Y: 4}).SetScale(0.75).Draw(buf)
+			icon.SetPos(gogl.Vec{X: 4, Y: 34}).SetSize(56, 28).Draw(buf)
+		})},
+		{"paint_linear", gogl.NewRect(56, 56, gogl.Vec{X: 4, Y: 4}).SetStyle(gogl.Style{
+			Paint: gogl.LinearGradient{
+				Start: gogl.Vec{X: 4, Y: 4},
+				End:   gogl.Vec{X: 60, Y: 60},
+				Stops: []gogl.ColourStop{{0, gogl.Red}, {0.5, gogl.Yellow}, {1, gogl.Blue}},
+			},
+		})},
+		{"paint_spread", drawableFunc(func(buf *gogl.FrameBuffer) {
+			stops := []gogl.ColourStop{{0, gogl.White}, {1, color.RGBA{0, 0, 255, 0}}}
+			for i, spread := range []gogl.SpreadMode{gogl.SpreadPad, gogl.SpreadRepeat, gogl.SpreadReflect} {
+				y := float64(2 + 21*i)
+				gogl.NewRect(60, 18, gogl.Vec{X: 2, Y: y}).SetStyle(gogl.Style{
+					Paint: gogl.LinearGradient{
+						Start:  gogl.Vec{X: 20, Y: y},
+						End:    gogl.Vec{X: 32, Y: y},
+						Stops:  stops,
+						Spread: spread,
+					},
+				}).Draw(buf)
+			}
+		})},
+		{"paint_radial_conic", drawableFunc(func(buf *gogl.FrameBuffer) {
+			gogl.NewCircle(30, gogl.Vec{X: 16, Y: 16}).SetStyle(gogl.Style{
+				Paint: gogl.RadialGradient{
+					Centre: gogl.Vec{X: 16, Y: 16},
+					Radius: 5,
+					Stops:  []gogl.ColourStop{{0, gogl.Orange}, {1, gogl.Purple}},
+					Spread: gogl.SpreadReflect,
+				},
+				AntiAlias: true,
+			}).Draw(buf)
+			gogl.NewCircle(30, gogl.Vec{X: 47, Y: 47}).SetStyle(gogl.Style{
+				Paint: gogl.ConicGradient{
+					Centre: gogl.Vec{X: 47, Y: 47},
+					Stops: []gogl.ColourStop{
+						{0, gogl.Red}, {1.0 / 3, gogl.Lime}, {2.0 / 3, gogl.Blue}, {1, gogl.Red},
+					},
+				},
+				Thickness: 8,
+				AntiAlias: true,
+			}).Draw(buf)
+		})},
+		{"paint_pattern_text", drawableFunc(func(buf *gogl.FrameBuffer) {
+			checks := gogl.NewFrameBuffer(2, 2)
+			checks.SetPixel(0, 0, gogl.NewPixel(gogl.Grey))
+			checks.SetPixel(1, 1, gogl.NewPixel(gogl.Grey))
+			gogl.NewPath().
+				MoveTo(gogl.Vec{X: 4, Y: 28}).
+				QuadTo(gogl.Vec{X: 32, Y: -16}, gogl.Vec{X: 60, Y: 28}).
+				Close().
+				SetStyle(gogl.Style{Paint: gogl.ImagePattern{Image: checks, Spread: gogl.SpreadRepeat}}).
+				Draw(buf)
+			gogl.NewText("grad", gogl.Vec{X: 4, Y: 32}, "fonts/luxisr.ttf").SetStyle(gogl.Style{
+				Paint: gogl.LinearGradient{
+					Start: gogl.Vec{X: 0, Y: 36},
+					End:   gogl.Vec{X: 0, Y: 56},
+					Stops: []gogl.ColourStop{{0, gogl.Cyan}, {1, gogl.Magenta}},
+				},
+			}).Draw(buf)
+		})},
+		{"sprite_transform", drawableFunc(func(buf *gogl.FrameBuffer) {
+			sheet, err := gogl.LoadSprite("testdata/sprites.png")
+			if err != nil {
+				panic(err)
+			}
+			frame := image.Rect(0, 0, 8, 8)
+			sheet.SetSource(frame).SetScale(gogl.Vec{X: 3, Y: 3}).SetPos(gogl.Vec{X: 2, Y: 2})
+			sheet.Draw(buf)
+			sheet.SetPos(gogl.Vec{X: 34, Y: 2})
+			sheet.SetSource(frame.Add(image.Pt(8, 0))).SetFlip(true, false).
+				SetTint(color.RGBA{255, 255, 255, 160}).Draw(buf)
+			sheet.SetPos(gogl.Vec{X: 46, Y: 46})
+			sheet.SetSource(frame).SetFlip(false, false).CentrePivot().SetRotation(math.Pi / 6).
+				SetScale(gogl.Vec{X: 4, Y: 2}).SetTint(gogl.Yellow).SetFilter(gogl.FilterBilinear).Draw(buf)
+		})},
+		{"transform_shapes", drawableFunc(func(buf *gogl.FrameBuffer) {
+			gogl.NewRect(16, 16, gogl.Vec{X: 16, Y: 2}).SetStyle(gogl.Style{Colour: gogl.Red, AntiAlias: true}).
+				SetTransform(gogl.Rotate(math.Pi / 4)).Draw(buf)
+			gogl.NewEllipse(20, 10, gogl.Vec{X: 46, Y: 16}).SetStyle(gogl.Style{Colour: gogl.Lime, AntiAlias: true}).
+				SetTransform(gogl.Skew(math.Pi/6, 0)).Draw(buf)
+			gogl.NewPolygon([]gogl.Vec{{X: 4, Y: 40}, {X: 16, Y: 36}, {X: 12, Y: 48}}).
+				SetStyle(gogl.Style{Colour: gogl.Yellow}).SetTransform(gogl.Scale(2, 1.5)).Draw(buf)
+			gogl.NewCurvedRect(24, 14, 4, gogl.Vec{X: 36, Y: 40}).SetStyle(gogl.Style{Colour: gogl.Cyan, Thickness: 2, AntiAlias: true}).
+				SetTransform(gogl.Rotate(-math.Pi / 8)).Draw(buf)
+		})},
+		{"scene_graph", drawableFunc(func(buf *gogl.FrameBuffer) {
+			// A group with a shape, text and a faded child, turned and moved together
+			body := gogl.NewNode(gogl.NewRect(24, 12, gogl.Vec{X: 0, Y: 0}).SetStyle(gogl.Style{Colour: gogl.Blue}))
+			label := gogl.NewNode(gogl.NewText("hi", gogl.Vec{X: 2, Y: -1}, "fonts/luxisr.ttf").SetColour(gogl.White).SetSize(12)).SetZ(1)
+			shadow := gogl.NewNode(gogl.NewCircle(16, gogl.Vec{X: 24, Y: 12}).SetStyle(gogl.Style{Colour: gogl.Red})).
+				SetZ(-1).SetOpacity(0.5)
+			group := gogl.NewNode(nil).Add(label, body, shadow).
+				SetTransform(gogl.Translate(gogl.Vec{X: 20, Y: 16}).Mul(gogl.Rotate(math.Pi / 8)))
+			hidden := gogl.NewNode(gogl.NewRect(64, 64, gogl.Vec{}).SetStyle(gogl.Style{Colour: gogl.White})).SetVisible(false)
+			gogl.NewNode(nil).Add(group, hidden).Draw(buf)
+		})},
+		{"blend_additive", drawableFunc(func(buf *gogl.FrameBuffer) {
+			glow := gogl.Style{Colour: color.RGBA{160, 60, 20, 255}, Blend: gogl.AdditiveBlend}
+			gogl.NewCircle(36, gogl.Vec{X: 24, Y: 24}).SetStyle(glow).Draw(buf)
+			gogl.NewCircle(36, gogl.Vec{X: 40, Y: 40}).SetStyle(glow).Draw(buf)
+		})},
+		{"opacity", drawableFunc(func(buf *gogl.FrameBuffer) {
+			faded := gogl.Style{Colour: gogl.White, Opacity: new(0.5)}
+			gogl.NewRect(40, 40, gogl.Vec{X: 4, Y: 4}).SetStyle(gogl.Style{Colour: gogl.Blue}).Draw(buf)
+			gogl.NewRect(40, 40, gogl.Vec{X: 20, Y: 20}).SetStyle(faded).Draw(buf)
+			gogl.NewText("fade", gogl.Vec{X: 4, Y: 40}, "fonts/luxisr.ttf").SetStyle(faded).Draw(buf)
+		})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gogltest.AssertDrawable(t, tc.name, tc.d, goldenOpts)
+		})
+	}
+}
+
+// drawableFunc allows a function to be used as a drawable.
+type drawableFunc func(buf *gogl.FrameBuffer)
+
+func (f drawableFunc) Draw(buf *gogl.FrameBuffer) { f(buf) }
+
+// bloomed returns a drawable which draws d, then applies a bloom effect to the frame
+// buffer.
+func bloomed(d gogl.Drawable, bloom gogl.Bloom) gogl.Drawable {
+	return drawableFunc(func(buf *gogl.FrameBuffer) {
+		d.Draw(buf)
+		bloom.Apply(buf)
+	})
+}
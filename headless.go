@@ -0,0 +1,111 @@
+package gogl
+
+import "image"
+
+// HeadlessBackend is a backend which renders in memory rather than to an OS window.
+// Input is scripted by queueing events and setting the mouse state, which allows
+// windows to be driven from tests or run on machines without a display.
+type HeadlessBackend struct {
+	width, height int
+	title         string
+
+	events     []Event
+	mousePos   Vec
+	mouseState MouseState
+
+	frame     *FrameBuffer
+	dirty     []image.Rectangle
+	presented int
+}
+
+var _ Backend = (*HeadlessBackend)(nil)
+
+// NewHeadlessBackend constructs a new headless backend.
+func NewHeadlessBackend() *HeadlessBackend {
+	return &HeadlessBackend{}
+}
+
+// Init stores the window dimensions and title.
+func (h *HeadlessBackend) Init(cfg WindowCfg) error {
+	h.width, h.height = cfg.Width, cfg.Height
+	h.title = cfg.Title
+	return nil
+}
+
+// Destroy does nothing, as the headless backend holds no OS resources.
+func (h *HeadlessBackend) Destroy() {}
+
+// PollEvent returns the next queued event.
+func (h *HeadlessBackend) PollEvent() (Event, bool) {
+	if len(h.events) == 0 {
+		return nil, false
+	}
+	e := h.events[0]
+	h.events = h.events[1:]
+	return e, true
+}
+
+// Present copies the dirty regions of the frame buffer into the backend's frame, which
+// can be retrieved with Frame.
+func (h *HeadlessBackend) Present(buf *FrameBuffer, dirty []image.Rectangle) error {
+	if h.frame == nil || h.frame.Bounds() != buf.Bounds() {
+		h.frame = NewFrameBuffer(buf.Width(), buf.Height())
+	}
+	for _, r := range dirty {
+		h.frame.Blit(buf, r, r.Min, SrcBlend)
+	}
+	h.dirty = dirty
+	h.presented++
+	return nil
+}
+
+// Mouse returns the scripted mouse location and button state.
+func (h *HeadlessBackend) Mouse() (Vec, MouseState) {
+	return h.mousePos, h.mouseState
+}
+
+// Size returns the dimensions of the window, in pixels.
+func (h *HeadlessBackend) Size() (int, int) {
+	return h.width, h.height
+}
+
+// SetTitle sets the title of the window.
+func (h *HeadlessBackend) SetTitle(title string) error {
+	h.title = title
+	return nil
+}
+
+// QueueEvents adds events to be processed on the next call to Window.Update.
+func (h *HeadlessBackend) QueueEvents(events ...Event) {
+	h.events = append(h.events, events...)
+}
+
+// SetMouse sets the location of the mouse cursor and the state of its buttons.
+func (h *HeadlessBackend) SetMouse(pos Vec, state MouseState) {
+	h.mousePos = pos
+	h.mouseState = state
+}
+
+// Title returns the current title of the window.
+func (h *HeadlessBackend) Title() string {
+	return h.title
+}
+
+// Frame returns a copy of the most recently presented frame, or nil if no frame
+// has been presented yet.
+func (h *HeadlessBackend) Frame() *FrameBuffer {
+	if h.frame == nil {
+		return nil
+	}
+	return h.frame.clone()
+}
+
+// DirtyRects returns the regions which changed in the most recently presented frame.
+func (h *HeadlessBackend) DirtyRects() []image.Rectangle {
+	return h.dirty
+}
+
+// FramesPresented returns the number of frames presented so far.
+func (h *HeadlessBackend) FramesPresented() int {
+	return h.presented
+}
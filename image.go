@@ -0,0 +1,161 @@
+package gogl
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ draw.Image = (*FrameBuffer)(nil)
+
+// ColorModel implements image.Image. Frame buffer pixels use straight (non-premultiplied)
+// alpha, unless the frame buffer is premultiplied.
+func (f *FrameBuffer) ColorModel() color.Model {
+	if f.premultiplied {
+		return color.RGBAModel
+	}
+	return color.NRGBAModel
+}
+
+// Bounds implements image.Image. The origin is always at (0, 0).
+func (f *FrameBuffer) Bounds() image.Rectangle {
+	return image.Rect(0, 0, f.width, f.height)
+}
+
+// At implements image.Image. It returns the colour of the pixel at the specified
+// coordinates, or transparent black if they are out of bounds. The colour is a
+// color.RGBA for premultiplied frame buffers, and a color.NRGBA otherwise.
+func (f *FrameBuffer) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(f.Bounds())) {
+		return color.NRGBA{}
+	}
+	p := f.getPixel(x, y)
+	if f.premultiplied {
+		return color.RGBA{p.R(), p.G(), p.B(), p.A()}
+	}
+	return color.NRGBA{p.R(), p.G(), p.B(), p.A()}
+}
+
+// Set implements draw.Image. It overwrites the pixel at the specified coordinates
+// without blending. If the coordinates are out of bounds or outside of the clip region,
+// nothing happens.
+func (f *FrameBuffer) Set(x, y int, c color.Color) {
+	if !f.inClip(x, y) {
+		return
+	}
+	f.setPixel(x, y, f.toNative(pixelFromColor(c)))
+}
+
+// NewFrameBufferFromImage constructs a new frame buffer containing a copy of an image.
+// The frame buffer's origin corresponds to the minimum point of the image's bounds.
+func NewFrameBufferFromImage(img image.Image) *FrameBuffer {
+	b := img.Bounds()
+	f := NewFrameBuffer(b.Dx(), b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			f.setPixel(x-b.Min.X, y-b.Min.Y, pixelFromColor(img.At(x, y)))
+		}
+	}
+	return f
+}
+
+// DecodeFrameBuffer decodes a PNG, JPEG or GIF image into a new frame buffer.
+func DecodeFrameBuffer(r io.Reader) (*FrameBuffer, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return NewFrameBufferFromImage(img), nil
+}
+
+// LoadFrameBuffer loads a PNG, JPEG or GIF image file into a new frame buffer.
+func LoadFrameBuffer(path string) (*FrameBuffer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodeFrameBuffer(file)
+}
+
+// ImageFormat is a file format that a frame buffer can be encoded as.
+type ImageFormat int
+
+const (
+	PNG ImageFormat = iota
+	JPEG
+	GIF
+)
+
+// String returns the name of the image format.
+func (i ImageFormat) String() string {
+	switch i {
+	case PNG:
+		return "png"
+	case JPEG:
+		return "jpeg"
+	case GIF:
+		return "gif"
+	default:
+		return "invalid"
+	}
+}
+
+// Encode writes the frame buffer to w in the given image format.
+func (f *FrameBuffer) Encode(w io.Writer, format ImageFormat) error {
+	switch format {
+	case PNG:
+		return png.Encode(w, f)
+	case JPEG:
+		return jpeg.Encode(w, f, nil)
+	case GIF:
+		return gif.Encode(w, f, nil)
+	default:
+		return fmt.Errorf("unsupported image format: %v", format)
+	}
+}
+
+// Save writes the frame buffer to an image file. The format is chosen from the file
+// extension, which must be .png, .jpg, .jpeg or .gif.
+func (f *FrameBuffer) Save(path string) error {
+	var format ImageFormat
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".png":
+		format = PNG
+	case ".jpg", ".jpeg":
+		format = JPEG
+	case ".gif":
+		format = GIF
+	default:
+		return fmt.Errorf("unsupported image file extension: %q", ext)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Encode(file, format); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// pixelFromColor converts any colour to a pixel, taking into account that colour
+// values from the standard library are alpha-premultiplied.
+func pixelFromColor(c color.Color) Pixel {
+	if p, ok := c.(Pixel); ok {
+		return p
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return pack(n.A, n.B, n.G, n.R)
+}
@@ -0,0 +1,57 @@
+package gogl
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestFrameBufferImageRoundTrip(t *testing.T) {
+	f := NewFrameBuffer(4, 3)
+	f.Fill(color.RGBA{10, 20, 30, 255})
+	f.Set(1, 2, color.NRGBA{200, 100, 50, 128})
+
+	var buf bytes.Buffer
+	if err := f.Encode(&buf, PNG); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeFrameBuffer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Bounds() != f.Bounds() {
+		t.Fatalf("Expected bounds %v, got %v", f.Bounds(), decoded.Bounds())
+	}
+	for y := range f.Height() {
+		for x := range f.Width() {
+			if want, got := f.GetPixel(x, y), decoded.GetPixel(x, y); want != got {
+				t.Errorf("Pixel (%d, %d): expected %#x, got %#x", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestFrameBufferDrawImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(1, 1, color.RGBA{0, 0, 255, 255})
+
+	// The frame buffer should be usable as a destination for the standard library
+	f := NewFrameBuffer(3, 3)
+	draw.Draw(f, image.Rect(1, 1, 3, 3), src, image.Point{}, draw.Src)
+
+	if got := f.GetPixel(2, 2); got != NewPixel(Blue) {
+		t.Errorf("Expected %#x, got %#x", NewPixel(Blue), got)
+	}
+	if got := f.At(1, 1); got != (color.NRGBA{}) {
+		t.Errorf("Expected transparent pixel, got %v", got)
+	}
+
+	// ...and as a source
+	fromFB := NewFrameBufferFromImage(f)
+	if got := fromFB.GetPixel(2, 2); got != NewPixel(Blue) {
+		t.Errorf("Expected %#x, got %#x", NewPixel(Blue), got)
+	}
+}
@@ -1,26 +1,22 @@
 package gogl
 
-import (
-	"github.com/jupiterrider/purego-sdl3/sdl"
-)
-
 // keyTracker is used to track which keys are pressed by the user and
 // react accordingly.
 type keyTracker struct {
-	keyBindingsInstant map[sdl.Keycode]func()
-	keyBindingsPress   map[sdl.Keycode]func()
-	keyBindingsRelease map[sdl.Keycode]func()
+	keyBindingsInstant map[Keycode]func()
+	keyBindingsPress   map[Keycode]func()
+	keyBindingsRelease map[Keycode]func()
 
-	pressedKeys map[sdl.Keycode]struct{}
+	pressedKeys map[Keycode]struct{}
 }
 
 // newKeyTracker constructs a key tracker object.
 func newKeyTracker() *keyTracker {
 	return &keyTracker{
-		keyBindingsInstant: make(map[sdl.Keycode]func()),
-		keyBindingsPress:   make(map[sdl.Keycode]func()),
-		keyBindingsRelease: make(map[sdl.Keycode]func()),
-		pressedKeys:        make(map[sdl.Keycode]struct{}),
+		keyBindingsInstant: make(map[Keycode]func()),
+		keyBindingsPress:   make(map[Keycode]func()),
+		keyBindingsRelease: make(map[Keycode]func()),
+		pressedKeys:        make(map[Keycode]struct{}),
 	}
 }
 
@@ -35,7 +31,7 @@ const (
 
 // registerKeybind sets a callback function which is executed when a key is pressed.
 // The callback is executed according to the KeybindMode.
-func (k *keyTracker) registerKeybind(key sdl.Keycode, mode KeybindMode, callback func()) {
+func (k *keyTracker) registerKeybind(key Keycode, mode KeybindMode, callback func()) {
 	switch mode {
 	case Instantaneous:
 		k.keyBindingsInstant[key] = callback
@@ -48,7 +44,7 @@ func (k *keyTracker) registerKeybind(key sdl.Keycode, mode KeybindMode, callback
 
 // registerKeybind sets a callback function which is executed when a key is pressed.
 // The callback can be executed always while the key is pressed, on press, or on release.
-func (k *keyTracker) unregisterKeybind(key sdl.Keycode, mode KeybindMode) {
+func (k *keyTracker) unregisterKeybind(key Keycode, mode KeybindMode) {
 	switch mode {
 	case Instantaneous:
 		delete(k.keyBindingsInstant, key)
@@ -61,19 +57,19 @@ func (k *keyTracker) unregisterKeybind(key sdl.Keycode, mode KeybindMode) {
 
 // DropKeybinds unregisters all keybinds.
 func (k *keyTracker) dropKeybinds() {
-	k.keyBindingsInstant = make(map[sdl.Keycode]func())
-	k.keyBindingsPress = make(map[sdl.Keycode]func())
-	k.keyBindingsRelease = make(map[sdl.Keycode]func())
+	k.keyBindingsInstant = make(map[Keycode]func())
+	k.keyBindingsPress = make(map[Keycode]func())
+	k.keyBindingsRelease = make(map[Keycode]func())
 }
 
 // Is pressed returns true if a key is currently pressed.
-func (k *keyTracker) isPressed(key sdl.Keycode) bool {
+func (k *keyTracker) isPressed(key Keycode) bool {
 	_, ok := k.pressedKeys[key]
 	return ok
 }
 
 // handleEvent processes key press events and keeps track of pressed keys.
-func (k *keyTracker) handleEvent(event sdl.KeyboardEvent) {
+func (k *keyTracker) handleEvent(event KeyEvent) {
 
 	if event.Down {
 		// Execute on-press callback
@@ -108,264 +104,271 @@ func (k keyTracker) update() {
 	}
 }
 
+// Keycode is a virtual key code. The codes match SDL's, so that the SDL backend can pass
+// them straight through.
+type Keycode uint32
+
+// Keymod is a set of key modifiers, which match SDL's.
+type Keymod uint16
+
 const (
 	// Key modifiers. See (https://wiki.libsdl.org/SDL_Keymod)
 
-	KeyModeNone   = sdl.KeymodNone   // 0 (no modifier is applicable)
-	KeyModeLShift = sdl.KeymodLShift // the left Shift key is down
-	KeyModeRShift = sdl.KeymodRShift // the right Shift key is down
-	KeyModeLCtrl  = sdl.KeymodLCtrl  // the left Ctrl (Control) key is down
-	KeyModeRCtrl  = sdl.KeymodRCtrl  // the right Ctrl (Control) key is down
-	KeyModeLAlt   = sdl.KeymodLAlt   // the left Alt key is down
-	KeyModeRAlt   = sdl.KeymodRAlt   // the right Alt key is down
-	KeyModeLGui   = sdl.KeymodLGui   // the left GUI key (often the Windows key) is down
-	KeyModeRGui   = sdl.KeymodRGui   // the right GUI key (often the Windows key) is down
-	KeyModeNum    = sdl.KeymodNum    // the Num Lock key (may be located on an extended keypad) is down
-	KeyModeCaps   = sdl.KeymodCaps   // the Caps Lock key is down
-	KeyModeMode   = sdl.KeymodMode   // the AltGr key is down
-	KeyModeCtrl   = sdl.KeymodCtrl   // (KMOD_LCTRL|KMOD_RCTRL)
-	KeyModeShift  = sdl.KeymodShift  // (KMOD_LSHIFT|KMOD_RSHIFT)
-	KeyModeAlt    = sdl.KeymodAlt    // (KMOD_LALT|KMOD_RALT)
-	KeyModeGui    = sdl.KeymodGui    // (KMOD_LGUI|KMOD_RGUI)
+	KeyModeNone   Keymod = 0x0000 // 0 (no modifier is applicable)
+	KeyModeLShift Keymod = 0x0001 // the left Shift key is down
+	KeyModeRShift Keymod = 0x0002 // the right Shift key is down
+	KeyModeLCtrl  Keymod = 0x0040 // the left Ctrl (Control) key is down
+	KeyModeRCtrl  Keymod = 0x0080 // the right Ctrl (Control) key is down
+	KeyModeLAlt   Keymod = 0x0100 // the left Alt key is down
+	KeyModeRAlt   Keymod = 0x0200 // the right Alt key is down
+	KeyModeLGui   Keymod = 0x0400 // the left GUI key (often the Windows key) is down
+	KeyModeRGui   Keymod = 0x0800 // the right GUI key (often the Windows key) is down
+	KeyModeNum    Keymod = 0x1000 // the Num Lock key (may be located on an extended keypad) is down
+	KeyModeCaps   Keymod = 0x2000 // the Caps Lock key is down
+	KeyModeMode   Keymod = 0x4000 // the AltGr key is down
+	KeyModeCtrl   Keymod = 0x00c0 // (KMOD_LCTRL|KMOD_RCTRL)
+	KeyModeShift  Keymod = 0x0003 // (KMOD_LSHIFT|KMOD_RSHIFT)
+	KeyModeAlt    Keymod = 0x0300 // (KMOD_LALT|KMOD_RALT)
+	KeyModeGui    Keymod = 0x0c00 // (KMOD_LGUI|KMOD_RGUI)
 
 	// SDL virtual key representation. See https://wiki.libsdl.org/SDL_Keycode
 	// and https://wiki.libsdl.org/SDLKeycodeLookup.
 
-	KeyUnknown           = sdl.KeycodeUnknown       // "" (no name, empty string)
-	KeyReturn            = sdl.KeycodeReturn        // "Return" (the Enter key (main keyboard))
-	KeyEscape            = sdl.KeycodeEscape        // "Escape" (the Esc key)
-	KeyBackspace         = sdl.KeycodeBackspace     // "Backspace"
-	KeyTab               = sdl.KeycodeTab           // "Tab" (the Tab key)
-	KeySpace             = sdl.KeycodeSpace         // "Space" (the Space Bar key(s))
-	KeyExclaim           = sdl.KeycodeExclaim       // "!"
-	KeycodeDblApostrophe = sdl.KeycodeDblApostrophe // """
-	KeyHash              = sdl.KeycodeHash          // "#"
-	KeyPercent           = sdl.KeycodePercent       // "%"
-	KeyDollar            = sdl.KeycodeDollar        // "$"
-	KeyAmpersand         = sdl.KeycodeAmpersand     // "&"
-	KeycodeApostrophe    = sdl.KeycodeApostrophe    // "'"
-	KeyLeftparen         = sdl.KeycodeLeftParen     // "("
-	KeyRightparen        = sdl.KeycodeRightParen    // ")"
-	KeyAsterisk          = sdl.KeycodeAsterisk      // "*"
-	KeyPlus              = sdl.KeycodePlus          // "+"
-	KeyComma             = sdl.KeycodeComma         // ","
-	KeyMinus             = sdl.KeycodeMinus         // "-"
-	KeyPeriod            = sdl.KeycodePeriod        // "."
-	KeySlash             = sdl.KeycodeSlash         // "/"
-	Key0                 = sdl.Keycode0             // "0"
-	Key1                 = sdl.Keycode1             // "1"
-	Key2                 = sdl.Keycode2             // "2"
-	Key3                 = sdl.Keycode3             // "3"
-	Key4                 = sdl.Keycode4             // "4"
-	Key5                 = sdl.Keycode5             // "5"
-	Key6                 = sdl.Keycode6             // "6"
-	Key7                 = sdl.Keycode7             // "7"
-	Key8                 = sdl.Keycode8             // "8"
-	Key9                 = sdl.Keycode9             // "9"
-	KeyColon             = sdl.KeycodeColon         // ":"
-	KeySemicolon         = sdl.KeycodeSemicolon     // ";"
-	KeyLess              = sdl.KeycodeLess          // "<"
-	KeyEquals            = sdl.KeycodeEquals        // "="
-	KeyGreater           = sdl.KeycodeGreater       // ">"
-	KeyQuestion          = sdl.KeycodeQuestion      // "?"
-	KeyAt                = sdl.KeycodeAt            // "@"
-
-	KeyLeftBracket  = sdl.KeycodeLeftBracket  // "["
-	KeyBackslash    = sdl.KeycodeBackslash    // "\"
-	KeyRightBracket = sdl.KeycodeRightBracket // "]"
-	KeyCaret        = sdl.KeycodeCaret        // "^"
-	KeyUnderscore   = sdl.KeycodeUnderscore   // "_"
-
-	KeyGrave = sdl.KeycodeGrave // "`"
-	KeyA     = sdl.KeycodeA     // "A"
-	KeyB     = sdl.KeycodeB     // "B"
-	KeyC     = sdl.KeycodeC     // "C"
-	KeyD     = sdl.KeycodeD     // "D"
-	KeyE     = sdl.KeycodeE     // "E"
-	KeyF     = sdl.KeycodeF     // "F"
-	KeyG     = sdl.KeycodeG     // "G"
-	KeyH     = sdl.KeycodeH     // "H"
-	KeyI     = sdl.KeycodeI     // "I"
-	KeyJ     = sdl.KeycodeJ     // "J"
-	KeyK     = sdl.KeycodeK     // "K"
-	KeyL     = sdl.KeycodeL     // "L"
-	KeyM     = sdl.KeycodeM     // "M"
-	KeyN     = sdl.KeycodeN     // "N"
-	KeyO     = sdl.KeycodeO     // "O"
-	KeyP     = sdl.KeycodeP     // "P"
-	KeyQ     = sdl.KeycodeQ     // "Q"
-	KeyR     = sdl.KeycodeR     // "R"
-	KeyS     = sdl.KeycodeS     // "S"
-	KeyT     = sdl.KeycodeT     // "T"
-	KeyU     = sdl.KeycodeU     // "U"
-	KeyV     = sdl.KeycodeV     // "V"
-	KeyW     = sdl.KeycodeW     // "W"
-	KeyX     = sdl.KeycodeX     // "X"
-	KeyY     = sdl.KeycodeY     // "Y"
-	KeyZ     = sdl.KeycodeZ     // "Z"
-
-	KeyCapslock = sdl.KeycodeCapsLock // "CapsLock"
-
-	KeyF1  = sdl.KeycodeF1  // "F1"
-	KeyF2  = sdl.KeycodeF2  // "F2"
-	KeyF3  = sdl.KeycodeF3  // "F3"
-	KeyF4  = sdl.KeycodeF4  // "F4"
-	KeyF5  = sdl.KeycodeF5  // "F5"
-	KeyF6  = sdl.KeycodeF6  // "F6"
-	KeyF7  = sdl.KeycodeF7  // "F7"
-	KeyF8  = sdl.KeycodeF8  // "F8"
-	KeyF9  = sdl.KeycodeF9  // "F9"
-	KeyF10 = sdl.KeycodeF10 // "F10"
-	KeyF11 = sdl.KeycodeF11 // "F11"
-	KeyF12 = sdl.KeycodeF12 // "F12"
-
-	KeyPrintscreen = sdl.KeycodePrintScreen // "PrintScreen"
-	KeyScrolllock  = sdl.KeycodeScrollLock  // "ScrollLock"
-	KeyPause       = sdl.KeycodePause       // "Pause" (the Pause / Break key)
-	KeyInsert      = sdl.KeycodeInsert      // "Insert" (insert on PC, help on some Mac keyboards (but does send code 73, not 117))
-	KeyHome        = sdl.KeycodeHome        // "Home"
-	KeyPageup      = sdl.KeycodePageUp      // "PageUp"
-	KeyDelete      = sdl.KeycodeDelete      // "Delete"
-	KeyEnd         = sdl.KeycodeEnd         // "End"
-	KeyPagedown    = sdl.KeycodePageDown    // "PageDown"
-	KeyRight       = sdl.KeycodeRight       // "Right" (the Right arrow key (navigation keypad))
-	KeyLeft        = sdl.KeycodeLeft        // "Left" (the Left arrow key (navigation keypad))
-	KeyDown        = sdl.KeycodeDown        // "Down" (the Down arrow key (navigation keypad))
-	KeyUp          = sdl.KeycodeUp          // "Up" (the Up arrow key (navigation keypad))
-
-	KeyNumlockclear = sdl.KeycodeNumLockClear // "Numlock" (the Num Lock key (PC) / the Clear key (Mac))
-	KeyKPDivide     = sdl.KeycodeKpDivide     // "Keypad /" (the / key (numeric keypad))
-	KeyKPMultiply   = sdl.KeycodeKpMultiply   // "Keypad *" (the * key (numeric keypad))
-	KeyKPMinus      = sdl.KeycodeKpMinus      // "Keypad -" (the - key (numeric keypad))
-	KeyKPPlus       = sdl.KeycodeKpPlus       // "Keypad +" (the + key (numeric keypad))
-	KeyKPEnter      = sdl.KeycodeKpEnter      // "Keypad Enter" (the Enter key (numeric keypad))
-	KeyKP1          = sdl.KeycodeKp1          // "Keypad 1" (the 1 key (numeric keypad))
-	KeyKP2          = sdl.KeycodeKp2          // "Keypad 2" (the 2 key (numeric keypad))
-	KeyKP3          = sdl.KeycodeKp3          // "Keypad 3" (the 3 key (numeric keypad))
-	KeyKP4          = sdl.KeycodeKp4          // "Keypad 4" (the 4 key (numeric keypad))
-	KeyKP5          = sdl.KeycodeKp5          // "Keypad 5" (the 5 key (numeric keypad))
-	KeyKP6          = sdl.KeycodeKp6          // "Keypad 6" (the 6 key (numeric keypad))
-	KeyKP7          = sdl.KeycodeKp7          // "Keypad 7" (the 7 key (numeric keypad))
-	KeyKP8          = sdl.KeycodeKp8          // "Keypad 8" (the 8 key (numeric keypad))
-	KeyKP9          = sdl.KeycodeKp9          // "Keypad 9" (the 9 key (numeric keypad))
-	KeyKP0          = sdl.KeycodeKp0          // "Keypad 0" (the 0 key (numeric keypad))
-	KeyKPPeriod     = sdl.KeycodeKpPeriod     // "Keypad ." (the . key (numeric keypad))
-
-	KeyApplication   = sdl.KeycodeApplication   // "Application" (the Application / Compose / Context Menu (Windows) key)
-	KeyPower         = sdl.KeycodePower         // "Power" (The USB document says this is a status flag, not a physical key - but some Mac keyboards do have a power key)
-	KeyKPEquals      = sdl.KeycodeKpEquals      // "Keypad =" (the = key (numeric keypad))
-	KeyF13           = sdl.KeycodeF13           // "F13"
-	KeyF14           = sdl.KeycodeF14           // "F14"
-	KeyF15           = sdl.KeycodeF15           // "F15"
-	KeyF16           = sdl.KeycodeF16           // "F16"
-	KeyF17           = sdl.KeycodeF17           // "F17"
-	KeyF18           = sdl.KeycodeF18           // "F18"
-	KeyF19           = sdl.KeycodeF19           // "F19"
-	KeyF20           = sdl.KeycodeF20           // "F20"
-	KeyF21           = sdl.KeycodeF21           // "F21"
-	KeyF22           = sdl.KeycodeF22           // "F22"
-	KeyF23           = sdl.KeycodeF23           // "F23"
-	KeyF24           = sdl.KeycodeF24           // "F24"
-	KeyExecute       = sdl.KeycodeExecute       // "Execute"
-	KeyHelp          = sdl.KeycodeHelp          // "Help"
-	KeyMenu          = sdl.KeycodeMenu          // "Menu"
-	KeySelect        = sdl.KeycodeSelect        // "Select"
-	KeyStop          = sdl.KeycodeStop          // "Stop"
-	KeyAgain         = sdl.KeycodeAgain         // "Again" (the Again key (Redo))
-	KeyUndo          = sdl.KeycodeUndo          // "Undo"
-	KeyCut           = sdl.KeycodeCut           // "Cut"
-	KeyCopy          = sdl.KeycodeCopy          // "Copy"
-	KeyPaste         = sdl.KeycodePaste         // "Paste"
-	KeyFind          = sdl.KeycodeFind          // "Find"
-	KeyMute          = sdl.KeycodeMute          // "Mute"
-	KeyVolumeUp      = sdl.KeycodeVolumeUp      // "VolumeUp"
-	KeyVolumeDown    = sdl.KeycodeVolumeDown    // "VolumeDown"
-	KeyKPComma       = sdl.KeycodeKpComma       // "Keypad ," (the Comma key (numeric keypad))
-	KeyKPEqualsAS400 = sdl.KeycodeKpEqualsAs400 // "Keypad = (AS400)" (the Equals AS400 key (numeric keypad))
-
-	KeyAltErase   = sdl.KeycodeAltErase  // "AltErase" (Erase-Eaze)
-	KeySysReq     = sdl.KeycodeSysReq    // "SysReq" (the SysReq key)
-	KeyCancel     = sdl.KeycodeCancel    // "Cancel"
-	KeyClear      = sdl.KeycodeClear     // "Clear"
-	KeyPrior      = sdl.KeycodePrior     // "Prior"
-	KeyReturn2    = sdl.KeycodeReturn    // "Return"
-	KeySeparator  = sdl.KeycodeSeparator // "Separator"
-	KeyOut        = sdl.KeycodeOut       // "Out"
-	KeyOper       = sdl.KeycodeOper      // "Oper"
-	KeyClearAgain = sdl.KeycodeClear     // "Clear / Again"
-	KeyCrSel      = sdl.KeycodeCrSel     // "CrSel"
-	KeyExSel      = sdl.KeycodeExSel     // "ExSel"
-
-	KeyKP00               = sdl.KeycodeKp00               // "Keypad 00" (the 00 key (numeric keypad))
-	KeyKP000              = sdl.KeycodeKp000              // "Keypad 000" (the 000 key (numeric keypad))
-	KeyThousandsSeparator = sdl.KeycodeThousandsSeparator // "ThousandsSeparator" (the Thousands Separator key)
-	KeyDecimalSeparator   = sdl.KeycodeDecimalSeparator   // "DecimalSeparator" (the Decimal Separator key)
-	KeyCurrencyUnit       = sdl.KeycodeCurrencyUnit       // "CurrencyUnit" (the Currency Unit key)
-	KeyCurrencySubunit    = sdl.KeycodeCurrencySubunit    // "CurrencySubUnit" (the Currency Subunit key)
-	KeyKPLeftParen        = sdl.KeycodeKpLeftParen        // "Keypad (" (the Left Parenthesis key (numeric keypad))
-	KeyKPRightParen       = sdl.KeycodeKpRightParen       // "Keypad )" (the Right Parenthesis key (numeric keypad))
-	KeyKPLeftBrace        = sdl.KeycodeKpLeftBrace        // "Keypad {" (the Left Brace key (numeric keypad))
-	KeyKPRightBrace       = sdl.KeycodeKpRightBrace       // "Keypad }" (the Right Brace key (numeric keypad))
-	KeyKPTab              = sdl.KeycodeKpTab              // "Keypad Tab" (the Tab key (numeric keypad))
-	KeyKPBackspace        = sdl.KeycodeKpBackspace        // "Keypad Backspace" (the Backspace key (numeric keypad))
-	KeyKPA                = sdl.KeycodeKpA                // "Keypad A" (the A key (numeric keypad))
-	KeyKPB                = sdl.KeycodeKpB                // "Keypad B" (the B key (numeric keypad))
-	KeyKPC                = sdl.KeycodeKpC                // "Keypad C" (the C key (numeric keypad))
-	KeyKPD                = sdl.KeycodeKpD                // "Keypad D" (the D key (numeric keypad))
-	KeyKPE                = sdl.KeycodeKpE                // "Keypad E" (the E key (numeric keypad))
-	KeyKPF                = sdl.KeycodeKpF                // "Keypad F" (the F key (numeric keypad))
-	KeyKPXor              = sdl.KeycodeKpXor              // "Keypad XOR" (the XOR key (numeric keypad))
-	KeyKPPower            = sdl.KeycodeKpPower            // "Keypad ^" (the Power key (numeric keypad))
-	KeyKPPercent          = sdl.KeycodeKpPercent          // "Keypad %" (the Percent key (numeric keypad))
-	KeyKPLess             = sdl.KeycodeKpLess             // "Keypad <" (the Less key (numeric keypad))
-	KeyKPGreater          = sdl.KeycodeKpGreater          // "Keypad >" (the Greater key (numeric keypad))
-	KeyKPAmpersand        = sdl.KeycodeKpAmpersand        // "Keypad &" (the & key (numeric keypad))
-	KeyKPDblAmpersand     = sdl.KeycodeKpDblAmpersand     // "Keypad &&" (the && key (numeric keypad))
-	KeyKPVerticalBar      = sdl.KeycodeKpVerticalBar      // "Keypad |" (the | key (numeric keypad))
-	KeyKPDblVerticalBar   = sdl.KeycodeKpDblVerticalBar   // "Keypad ||" (the || key (numeric keypad))
-	KeyKPColon            = sdl.KeycodeKpColon            // "Keypad :" (the : key (numeric keypad))
-	KeyKPHash             = sdl.KeycodeKpHash             // "Keypad #" (the # key (numeric keypad))
-	KeyKPSpace            = sdl.KeycodeKpSpace            // "Keypad Space" (the Space key (numeric keypad))
-	KeyKPAt               = sdl.KeycodeKpAt               // "Keypad @" (the @ key (numeric keypad))
-	KeyKPExclam           = sdl.KeycodeKpExclam           // "Keypad !" (the ! key (numeric keypad))
-	KeyKPMemStore         = sdl.KeycodeKpMemStore         // "Keypad MemStore" (the Mem Store key (numeric keypad))
-	KeyKPMemRecall        = sdl.KeycodeKpMemRecall        // "Keypad MemRecall" (the Mem Recall key (numeric keypad))
-	KeyKPMemClear         = sdl.KeycodeKpMemClear         // "Keypad MemClear" (the Mem Clear key (numeric keypad))
-	KeyKPMemAdd           = sdl.KeycodeKpMemAdd           // "Keypad MemAdd" (the Mem Add key (numeric keypad))
-	KeyKPMemSubtract      = sdl.KeycodeKpMemSubtract      // "Keypad MemSubtract" (the Mem Subtract key (numeric keypad))
-	KeyKPMemMultiply      = sdl.KeycodeKpMemMultiply      // "Keypad MemMultiply" (the Mem Multiply key (numeric keypad))
-	KeyKPMemDivide        = sdl.KeycodeKpMemDivide        // "Keypad MemDivide" (the Mem Divide key (numeric keypad))
-	KeyKPPlusMinus        = sdl.KeycodeKpPlusMinus        // "Keypad +/-" (the +/- key (numeric keypad))
-	KeyKPClear            = sdl.KeycodeKpClear            // "Keypad Clear" (the Clear key (numeric keypad))
-	KeyKPClearEntry       = sdl.KeycodeKpClearEntry       // "Keypad ClearEntry" (the Clear Entry key (numeric keypad))
-	KeyKPBinary           = sdl.KeycodeKpBinary           // "Keypad Binary" (the Binary key (numeric keypad))
-	KeyKPOctal            = sdl.KeycodeKpOctal            // "Keypad Octal" (the Octal key (numeric keypad))
-	KeyKPDecimal          = sdl.KeycodeKpDecimal          // "Keypad Decimal" (the Decimal key (numeric keypad))
-	KeyKPHexadecimal      = sdl.KeycodeKpHexadecimal      // "Keypad Hexadecimal" (the Hexadecimal key (numeric keypad))
-
-	KeyLCtrl  = sdl.KeycodeLCtrl  // "Left Ctrl"
-	KeyLShift = sdl.KeycodeLShift // "Left Shift"
-	KeyLAlt   = sdl.KeycodeLAlt   // "Left Alt" (alt, option)
-	KeyLGui   = sdl.KeycodeLGui   // "Left GUI" (windows, command (apple), meta)
-	KeyRCtrl  = sdl.KeycodeRCtrl  // "Right Ctrl"
-	KeyRShift = sdl.KeycodeRShift // "Right Shift"
-	KeyRAlt   = sdl.KeycodeRAlt   // "Right Alt" (alt, option)
-	KeyRGui   = sdl.KeycodeRGui   // "Right GUI" (windows, command (apple), meta)
-
-	KeyMode = sdl.KeycodeMode // "ModeSwitch"
-
-	KeyMediaNextTrack = sdl.KeycodeMediaNextTrack     // "AudioNext" (the Next Track media key)
-	KeyMediaPrevTrack = sdl.KeycodeMediaPreviousTrack // "AudioPrev" (the Previous Track media key)
-	KeyMediaStop      = sdl.KeycodeMediaStop          // "AudioStop" (the Stop media key)
-	KeyMediaPlay      = sdl.KeycodeMediaPlay          // "AudioPlay" (the Play media key)
-	KeyMediaSelect    = sdl.KeycodeMediaSelect        // "MediaSelect" (the Media Select key)
-	KeyACSearch       = sdl.KeycodeAcSearch           // "AC Search" (the Search key (application control keypad))
-	KeyACHome         = sdl.KeycodeAcHome             // "AC Home" (the Home key (application control keypad))
-	KeyACBack         = sdl.KeycodeAcBack             // "AC Back" (the Back key (application control keypad))
-	KeyACForward      = sdl.KeycodeAcForward          // "AC Forward" (the Forward key (application control keypad))
-	KeyACStop         = sdl.KeycodeAcStop             // "AC Stop" (the Stop key (application control keypad))
-	KeyACRefresh      = sdl.KeycodeAcRefresh          // "AC Refresh" (the Refresh key (application control keypad))
-	KeyACBookmarks    = sdl.KeycodeAcBookmarks        // "AC Bookmarks" (the Bookmarks key (application control keypad))
-	KeyMediaEject     = sdl.KeycodeMediaEject         // "Eject" (the Eject key)
-	KeySleep          = sdl.KeycodeSleep              // "Sleep" (the Sleep key)
+	KeyUnknown           Keycode = 0x00000000 // "" (no name, empty string)
+	KeyReturn            Keycode = 0x0000000d // "Return" (the Enter key (main keyboard))
+	KeyEscape            Keycode = 0x0000001b // "Escape" (the Esc key)
+	KeyBackspace         Keycode = 0x00000008 // "Backspace"
+	KeyTab               Keycode = 0x00000009 // "Tab" (the Tab key)
+	KeySpace             Keycode = 0x00000020 // "Space" (the Space Bar key(s))
+	KeyExclaim           Keycode = 0x00000021 // "!"
+	KeycodeDblApostrophe Keycode = 0x00000022 // """
+	KeyHash              Keycode = 0x00000023 // "#"
+	KeyPercent           Keycode = 0x00000025 // "%"
+	KeyDollar            Keycode = 0x00000024 // "$"
+	KeyAmpersand         Keycode = 0x00000026 // "&"
+	KeycodeApostrophe    Keycode = 0x00000027 // "'"
+	KeyLeftparen         Keycode = 0x00000028 // "("
+	KeyRightparen        Keycode = 0x00000029 // ")"
+	KeyAsterisk          Keycode = 0x0000002a // "*"
+	KeyPlus              Keycode = 0x0000002b // "+"
+	KeyComma             Keycode = 0x0000002c // ","
+	KeyMinus             Keycode = 0x0000002d // "-"
+	KeyPeriod            Keycode = 0x0000002e // "."
+	KeySlash             Keycode = 0x0000002f // "/"
+	Key0                 Keycode = 0x00000030 // "0"
+	Key1                 Keycode = 0x00000031 // "1"
+	Key2                 Keycode = 0x00000032 // "2"
+	Key3                 Keycode = 0x00000033 // "3"
+	Key4                 Keycode = 0x00000034 // "4"
+	Key5                 Keycode = 0x00000035 // "5"
+	Key6                 Keycode = 0x00000036 // "6"
+	Key7                 Keycode = 0x00000037 // "7"
+	Key8                 Keycode = 0x00000038 // "8"
+	Key9                 Keycode = 0x00000039 // "9"
+	KeyColon             Keycode = 0x0000003a // ":"
+	KeySemicolon         Keycode = 0x0000003b // ";"
+	KeyLess              Keycode = 0x0000003c // "<"
+	KeyEquals            Keycode = 0x0000003d // "="
+	KeyGreater           Keycode = 0x0000003e // ">"
+	KeyQuestion          Keycode = 0x0000003f // "?"
+	KeyAt                Keycode = 0x00000040 // "@"
+
+	KeyLeftBracket  Keycode = 0x0000005b // "["
+	KeyBackslash    Keycode = 0x0000005c // "\"
+	KeyRightBracket Keycode = 0x0000005d // "]"
+	KeyCaret        Keycode = 0x0000005e // "^"
+	KeyUnderscore   Keycode = 0x0000005f // "_"
+
+	KeyGrave Keycode = 0x00000060 // "`"
+	KeyA     Keycode = 0x00000061 // "A"
+	KeyB     Keycode = 0x00000062 // "B"
+	KeyC     Keycode = 0x00000063 // "C"
+	KeyD     Keycode = 0x00000064 // "D"
+	KeyE     Keycode = 0x00000065 // "E"
+	KeyF     Keycode = 0x00000066 // "F"
+	KeyG     Keycode = 0x00000067 // "G"
+	KeyH     Keycode = 0x00000068 // "H"
+	KeyI     Keycode = 0x00000069 // "I"
+	KeyJ     Keycode = 0x0000006a // "J"
+	KeyK     Keycode = 0x0000006b // "K"
+	KeyL     Keycode = 0x0000006c // "L"
+	KeyM     Keycode = 0x0000006d // "M"
+	KeyN     Keycode = 0x0000006e // "N"
+	KeyO     Keycode = 0x0000006f // "O"
+	KeyP     Keycode = 0x00000070 // "P"
+	KeyQ     Keycode = 0x00000071 // "Q"
+	KeyR     Keycode = 0x00000072 // "R"
+	KeyS     Keycode = 0x00000073 // "S"
+	KeyT     Keycode = 0x00000074 // "T"
+	KeyU     Keycode = 0x00000075 // "U"
+	KeyV     Keycode = 0x00000076 // "V"
+	KeyW     Keycode = 0x00000077 // "W"
+	KeyX     Keycode = 0x00000078 // "X"
+	KeyY     Keycode = 0x00000079 // "Y"
+	KeyZ     Keycode = 0x0000007a // "Z"
+
+	KeyCapslock Keycode = 0x40000039 // "CapsLock"
+
+	KeyF1  Keycode = 0x4000003a // "F1"
+	KeyF2  Keycode = 0x4000003b // "F2"
+	KeyF3  Keycode = 0x4000003c // "F3"
+	KeyF4  Keycode = 0x4000003d // "F4"
+	KeyF5  Keycode = 0x4000003e // "F5"
+	KeyF6  Keycode = 0x4000003f // "F6"
+	KeyF7  Keycode = 0x40000040 // "F7"
+	KeyF8  Keycode = 0x40000041 // "F8"
+	KeyF9  Keycode = 0x40000042 // "F9"
+	KeyF10 Keycode = 0x40000043 // "F10"
+	KeyF11 Keycode = 0x40000044 // "F11"
+	KeyF12 Keycode = 0x40000045 // "F12"
+
+	KeyPrintscreen Keycode = 0x40000046 // "PrintScreen"
+	KeyScrolllock  Keycode = 0x40000047 // "ScrollLock"
+	KeyPause       Keycode = 0x40000048 // "Pause" (the Pause / Break key)
+	KeyInsert      Keycode = 0x40000049 // "Insert" (insert on PC, help on some Mac keyboards (but does send code 73, not 117))
+	KeyHome        Keycode = 0x4000004a // "Home"
+	KeyPageup      Keycode = 0x4000004b // "PageUp"
+	KeyDelete      Keycode = 0x0000007f // "Delete"
+	KeyEnd         Keycode = 0x4000004d // "End"
+	KeyPagedown    Keycode = 0x4000004e // "PageDown"
+	KeyRight       Keycode = 0x4000004f // "Right" (the Right arrow key (navigation keypad))
+	KeyLeft        Keycode = 0x40000050 // "Left" (the Left arrow key (navigation keypad))
+	KeyDown        Keycode = 0x40000051 // "Down" (the Down arrow key (navigation keypad))
+	KeyUp          Keycode = 0x40000052 // "Up" (the Up arrow key (navigation keypad))
+
+	KeyNumlockclear Keycode = 0x40000053 // "Numlock" (the Num Lock key (PC) / the Clear key (Mac))
+	KeyKPDivide     Keycode = 0x40000054 // "Keypad /" (the / key (numeric keypad))
+	KeyKPMultiply   Keycode = 0x40000055 // "Keypad *" (the * key (numeric keypad))
+	KeyKPMinus      Keycode = 0x40000056 // "Keypad -" (the - key (numeric keypad))
+	KeyKPPlus       Keycode = 0x40000057 // "Keypad +" (the + key (numeric keypad))
+	KeyKPEnter      Keycode = 0x40000058 // "Keypad Enter" (the Enter key (numeric keypad))
+	KeyKP1          Keycode = 0x40000059 // "Keypad 1" (the 1 key (numeric keypad))
+	KeyKP2          Keycode = 0x4000005a // "Keypad 2" (the 2 key (numeric keypad))
+	KeyKP3          Keycode = 0x4000005b // "Keypad 3" (the 3 key (numeric keypad))
+	KeyKP4          Keycode = 0x4000005c // "Keypad 4" (the 4 key (numeric keypad))
+	KeyKP5          Keycode = 0x4000005d // "Keypad 5" (the 5 key (numeric keypad))
+	KeyKP6          Keycode = 0x4000005e // "Keypad 6" (the 6 key (numeric keypad))
+	KeyKP7          Keycode = 0x4000005f // "Keypad 7" (the 7 key (numeric keypad))
+	KeyKP8          Keycode = 0x40000060 // "Keypad 8" (the 8 key (numeric keypad))
+	KeyKP9          Keycode = 0x40000061 // "Keypad 9" (the 9 key (numeric keypad))
+	KeyKP0          Keycode = 0x40000062 // "Keypad 0" (the 0 key (numeric keypad))
+	KeyKPPeriod     Keycode = 0x40000063 // "Keypad ." (the . key (numeric keypad))
+
+	KeyApplication   Keycode = 0x40000065 // "Application" (the Application / Compose / Context Menu (Windows) key)
+	KeyPower         Keycode = 0x40000066 // "Power" (The USB document says this is a status flag, not a physical key - but some Mac keyboards do have a power key)
+	KeyKPEquals      Keycode = 0x40000067 // "Keypad =" (the = key (numeric keypad))
+	KeyF13           Keycode = 0x40000068 // "F13"
+	KeyF14           Keycode = 0x40000069 // "F14"
+	KeyF15           Keycode = 0x4000006a // "F15"
+	KeyF16           Keycode = 0x4000006b // "F16"
+	KeyF17           Keycode = 0x4000006c // "F17"
+	KeyF18           Keycode = 0x4000006d // "F18"
+	KeyF19           Keycode = 0x4000006e // "F19"
+	KeyF20           Keycode = 0x4000006f // "F20"
+	KeyF21           Keycode = 0x40000070 // "F21"
+	KeyF22           Keycode = 0x40000071 // "F22"
+	KeyF23           Keycode = 0x40000072 // "F23"
+	KeyF24           Keycode = 0x40000073 // "F24"
+	KeyExecute       Keycode = 0x40000074 // "Execute"
+	KeyHelp          Keycode = 0x40000075 // "Help"
+	KeyMenu          Keycode = 0x40000076 // "Menu"
+	KeySelect        Keycode = 0x40000077 // "Select"
+	KeyStop          Keycode = 0x40000078 // "Stop"
+	KeyAgain         Keycode = 0x40000079 // "Again" (the Again key (Redo))
+	KeyUndo          Keycode = 0x4000007a // "Undo"
+	KeyCut           Keycode = 0x4000007b // "Cut"
+	KeyCopy          Keycode = 0x4000007c // "Copy"
+	KeyPaste         Keycode = 0x4000007d // "Paste"
+	KeyFind          Keycode = 0x4000007e // "Find"
+	KeyMute          Keycode = 0x4000007f // "Mute"
+	KeyVolumeUp      Keycode = 0x40000080 // "VolumeUp"
+	KeyVolumeDown    Keycode = 0x40000081 // "VolumeDown"
+	KeyKPComma       Keycode = 0x40000085 // "Keypad ," (the Comma key (numeric keypad))
+	KeyKPEqualsAS400 Keycode = 0x40000086 // "Keypad = (AS400)" (the Equals AS400 key (numeric keypad))
+
+	KeyAltErase   Keycode = 0x40000099 // "AltErase" (Erase-Eaze)
+	KeySysReq     Keycode = 0x4000009a // "SysReq" (the SysReq key)
+	KeyCancel     Keycode = 0x4000009b // "Cancel"
+	KeyClear      Keycode = 0x4000009c // "Clear"
+	KeyPrior      Keycode = 0x4000009d // "Prior"
+	KeyReturn2    Keycode = 0x0000000d // "Return"
+	KeySeparator  Keycode = 0x4000009f // "Separator"
+	KeyOut        Keycode = 0x400000a0 // "Out"
+	KeyOper       Keycode = 0x400000a1 // "Oper"
+	KeyClearAgain Keycode = 0x4000009c // "Clear / Again"
+	KeyCrSel      Keycode = 0x400000a3 // "CrSel"
+	KeyExSel      Keycode = 0x400000a4 // "ExSel"
+
+	KeyKP00               Keycode = 0x400000b0 // "Keypad 00" (the 00 key (numeric keypad))
+	KeyKP000              Keycode = 0x400000b1 // "Keypad 000" (the 000 key (numeric keypad))
+	KeyThousandsSeparator Keycode = 0x400000b2 // "ThousandsSeparator" (the Thousands Separator key)
+	KeyDecimalSeparator   Keycode = 0x400000b3 // "DecimalSeparator" (the Decimal Separator key)
+	KeyCurrencyUnit       Keycode = 0x400000b4 // "CurrencyUnit" (the Currency Unit key)
+	KeyCurrencySubunit    Keycode = 0x400000b5 // "CurrencySubUnit" (the Currency Subunit key)
+	KeyKPLeftParen        Keycode = 0x400000b6 // "Keypad (" (the Left Parenthesis key (numeric keypad))
+	KeyKPRightParen       Keycode = 0x400000b7 // "Keypad )" (the Right Parenthesis key (numeric keypad))
+	KeyKPLeftBrace        Keycode = 0x400000b8 // "Keypad {" (the Left Brace key (numeric keypad))
+	KeyKPRightBrace       Keycode = 0x400000b9 // "Keypad }" (the Right Brace key (numeric keypad))
+	KeyKPTab              Keycode = 0x400000ba // "Keypad Tab" (the Tab key (numeric keypad))
+	KeyKPBackspace        Keycode = 0x400000bb // "Keypad Backspace" (the Backspace key (numeric keypad))
+	KeyKPA                Keycode = 0x400000bc // "Keypad A" (the A key (numeric keypad))
+	KeyKPB                Keycode = 0x400000bd // "Keypad B" (the B key (numeric keypad))
+	KeyKPC                Keycode = 0x400000be // "Keypad C" (the C key (numeric keypad))
+	KeyKPD                Keycode = 0x400000bf // "Keypad D" (the D key (numeric keypad))
+	KeyKPE                Keycode = 0x400000c0 // "Keypad E" (the E key (numeric keypad))
+	KeyKPF                Keycode = 0x400000c1 // "Keypad F" (the F key (numeric keypad))
+	KeyKPXor              Keycode = 0x400000c2 // "Keypad XOR" (the XOR key (numeric keypad))
+	KeyKPPower            Keycode = 0x400000c3 // "Keypad ^" (the Power key (numeric keypad))
+	KeyKPPercent          Keycode = 0x400000c4 // "Keypad %" (the Percent key (numeric keypad))
+	KeyKPLess             Keycode = 0x400000c5 // "Keypad <" (the Less key (numeric keypad))
+	KeyKPGreater          Keycode = 0x400000c6 // "Keypad >" (the Greater key (numeric keypad))
+	KeyKPAmpersand        Keycode = 0x400000c7 // "Keypad &" (the & key (numeric keypad))
+	KeyKPDblAmpersand     Keycode = 0x400000c8 // "Keypad &&" (the && key (numeric keypad))
+	KeyKPVerticalBar      Keycode = 0x400000c9 // "Keypad |" (the | key (numeric keypad))
+	KeyKPDblVerticalBar   Keycode = 0x400000ca // "Keypad ||" (the || key (numeric keypad))
+	KeyKPColon            Keycode = 0x400000cb // "Keypad :" (the : key (numeric keypad))
+	KeyKPHash             Keycode = 0x400000cc // "Keypad #" (the # key (numeric keypad))
+	KeyKPSpace            Keycode = 0x400000cd // "Keypad Space" (the Space key (numeric keypad))
+	KeyKPAt               Keycode = 0x400000ce // "Keypad @" (the @ key (numeric keypad))
+	KeyKPExclam           Keycode = 0x400000cf // "Keypad !" (the ! key (numeric keypad))
+	KeyKPMemStore         Keycode = 0x400000d0 // "Keypad MemStore" (the Mem Store key (numeric keypad))
+	KeyKPMemRecall        Keycode = 0x400000d1 // "Keypad MemRecall" (the Mem Recall key (numeric keypad))
+	KeyKPMemClear         Keycode = 0x400000d2 // "Keypad MemClear" (the Mem Clear key (numeric keypad))
+	KeyKPMemAdd           Keycode = 0x400000d3 // "Keypad MemAdd" (the Mem Add key (numeric keypad))
+	KeyKPMemSubtract      Keycode = 0x400000d4 // "Keypad MemSubtract" (the Mem Subtract key (numeric keypad))
+	KeyKPMemMultiply      Keycode = 0x400000d5 // "Keypad MemMultiply" (the Mem Multiply key (numeric keypad))
+	KeyKPMemDivide        Keycode = 0x400000d6 // "Keypad MemDivide" (the Mem Divide key (numeric keypad))
+	KeyKPPlusMinus        Keycode = 0x400000d7 // "Keypad +/-" (the +/- key (numeric keypad))
+	KeyKPClear            Keycode = 0x400000d8 // "Keypad Clear" (the Clear key (numeric keypad))
+	KeyKPClearEntry       Keycode = 0x400000d9 // "Keypad ClearEntry" (the Clear Entry key (numeric keypad))
+	KeyKPBinary           Keycode = 0x400000da // "Keypad Binary" (the Binary key (numeric keypad))
+	KeyKPOctal            Keycode = 0x400000db // "Keypad Octal" (the Octal key (numeric keypad))
+	KeyKPDecimal          Keycode = 0x400000dc // "Keypad Decimal" (the Decimal key (numeric keypad))
+	KeyKPHexadecimal      Keycode = 0x400000dd // "Keypad Hexadecimal" (the Hexadecimal key (numeric keypad))
+
+	KeyLCtrl  Keycode = 0x400000e0 // "Left Ctrl"
+	KeyLShift Keycode = 0x400000e1 // "Left Shift"
+	KeyLAlt   Keycode = 0x400000e2 // "Left Alt" (alt, option)
+	KeyLGui   Keycode = 0x400000e3 // "Left GUI" (windows, command (apple), meta)
+	KeyRCtrl  Keycode = 0x400000e4 // "Right Ctrl"
+	KeyRShift Keycode = 0x400000e5 // "Right Shift"
+	KeyRAlt   Keycode = 0x400000e6 // "Right Alt" (alt, option)
+	KeyRGui   Keycode = 0x400000e7 // "Right GUI" (windows, command (apple), meta)
+
+	KeyMode Keycode = 0x40000101 // "ModeSwitch"
+
+	KeyMediaNextTrack Keycode = 0x4000010b // "AudioNext" (the Next Track media key)
+	KeyMediaPrevTrack Keycode = 0x4000010c // "AudioPrev" (the Previous Track media key)
+	KeyMediaStop      Keycode = 0x4000010d // "AudioStop" (the Stop media key)
+	KeyMediaPlay      Keycode = 0x40000106 // "AudioPlay" (the Play media key)
+	KeyMediaSelect    Keycode = 0x40000110 // "MediaSelect" (the Media Select key)
+	KeyACSearch       Keycode = 0x40000118 // "AC Search" (the Search key (application control keypad))
+	KeyACHome         Keycode = 0x40000119 // "AC Home" (the Home key (application control keypad))
+	KeyACBack         Keycode = 0x4000011a // "AC Back" (the Back key (application control keypad))
+	KeyACForward      Keycode = 0x4000011b // "AC Forward" (the Forward key (application control keypad))
+	KeyACStop         Keycode = 0x4000011c // "AC Stop" (the Stop key (application control keypad))
+	KeyACRefresh      Keycode = 0x4000011d // "AC Refresh" (the Refresh key (application control keypad))
+	KeyACBookmarks    Keycode = 0x4000011e // "AC Bookmarks" (the Bookmarks key (application control keypad))
+	KeyMediaEject     Keycode = 0x4000010e // "Eject" (the Eject key)
+	KeySleep          Keycode = 0x40000102 // "Sleep" (the Sleep key)
 )
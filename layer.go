@@ -0,0 +1,140 @@
+package gogl
+
+import (
+	"image"
+)
+
+// Names of the layers which every window has. In order from bottom to top, their
+// z-indices are 0, 100, 200 and 300.
+const (
+	LayerBackground = "background"
+	LayerWorld      = "world"
+	LayerUI         = "ui"
+	LayerDebug      = "debug"
+)
+
+// PostEffect changes the pixels of a layer after everything on it has been drawn.
+type PostEffect interface {
+	// Apply changes the pixels of the frame buffer. The frame buffer is transparent apart
+	// from what was drawn on the layer, and the regions that were drawn on are dirty.
+	// Pixels which are changed must be marked dirty to be shown.
+	Apply(buf *FrameBuffer)
+}
+
+// Layer is a named layer of a window, which drawables can be drawn onto. Layers are drawn
+// onto the window's frame buffer in order of z-index, so that drawables on higher layers
+// cover those on lower layers, whatever order they were drawn in.
+type Layer struct {
+	name    string
+	z       int
+	visible bool
+	effect  PostEffect
+	camera  *Camera
+	scene   *Node
+
+	queue     []Drawable
+	offscreen offscreen // used to draw layers with an effect
+}
+
+// newLayer constructs a visible layer.
+func newLayer(name string, z int) *Layer {
+	return &Layer{name: name, z: z, visible: true}
+}
+
+// Name returns the name of the layer.
+func (l *Layer) Name() string {
+	return l.name
+}
+
+// Z returns the layer's z-index.
+func (l *Layer) Z() int {
+	return l.z
+}
+
+// SetZ sets the layer's z-index. Layers are drawn in order of z-index, then in the order
+// they were added.
+func (l *Layer) SetZ(z int) *Layer {
+	l.z = z
+	return l
+}
+
+// Visible returns whether the layer is shown.
+func (l *Layer) Visible() bool {
+	return l.visible
+}
+
+// SetVisible shows or hides the layer. Drawables drawn onto a hidden layer are dropped.
+func (l *Layer) SetVisible(visible bool) *Layer {
+	l.visible = visible
+	return l
+}
+
+// Effect returns the layer's post effect, or nil if it doesn't have one.
+func (l *Layer) Effect() PostEffect {
+	return l.effect
+}
+
+// SetEffect sets a post effect which is applied to the layer on every frame. The layer is
+// then drawn onto its own transparent frame buffer, which is blended onto the window's
+// frame buffer after the effect has been applied. Pass nil to remove the effect.
+func (l *Layer) SetEffect(e PostEffect) *Layer {
+	l.effect = e
+	return l
+}
+
+// Camera returns the camera the layer is seen through, or nil if it doesn't have one.
+func (l *Layer) Camera() *Camera {
+	return l.camera
+}
+
+// SetCamera sets the camera which the layer is seen through, so that drawables on it are
+// positioned in world coordinates. Drawables which can't be transformed, such as buttons,
+// are still drawn in window coordinates, unless they are in a scene graph. Pass nil to draw
+// everything in window coordinates.
+func (l *Layer) SetCamera(c *Camera) *Layer {
+	l.camera = c
+	return l
+}
+
+// Scene returns the root node of the layer's scene graph, or nil if it doesn't have one.
+func (l *Layer) Scene() *Node {
+	return l.scene
+}
+
+// SetScene sets the root node of a scene graph, which is drawn onto the layer on every
+// frame before its other drawables. Pass nil to stop drawing the scene.
+func (l *Layer) SetScene(root *Node) *Layer {
+	l.scene = root
+	return l
+}
+
+// render draws the layer's scene and queued drawables onto the frame buffer, then empties
+// the queue.
+func (l *Layer) render(buf *FrameBuffer, renderer Renderer) {
+	queue := l.queue
+	l.queue = nil
+	if !l.visible {
+		return
+	}
+	switch {
+	case l.scene != nil && l.camera != nil:
+		// The camera flattens the scene through its view, so that drawables in the scene
+		// which can't be transformed still move with the rest of it
+		queue = l.camera.view(append([]Drawable{l.scene}, queue...))
+	case l.scene != nil:
+		queue = append(l.scene.flatten(IdentityTransform, 1, nil), queue...)
+	case l.camera != nil:
+		queue = l.camera.view(queue)
+	}
+	if l.effect == nil {
+		renderer.Render(buf, queue)
+		return
+	}
+
+	l.offscreen.draw(buf, func(off *FrameBuffer) {
+		renderer.Render(off, queue)
+		l.effect.Apply(off)
+	}, func(off *FrameBuffer, area image.Rectangle) {
+		buf.Blit(off, area, area.Min, nil)
+	})
+}
@@ -0,0 +1,93 @@
+package gogl
+
+import (
+	"testing"
+)
+
+// effectFunc is a post effect which calls a function.
+type effectFunc func(buf *FrameBuffer)
+
+func (f effectFunc) Apply(buf *FrameBuffer) { f(buf) }
+
+func TestWindowLayers(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 10, 10)
+	square := func(c Pixel) *Rect {
+		return NewRect(10, 10, Vec{0, 0}).SetStyle(Style{Colour: c})
+	}
+
+	// Higher layers cover lower ones, whatever order they were drawn in
+	win.DrawOn(LayerUI, square(NewPixel(Red)))
+	win.Draw(square(NewPixel(Blue)))
+	win.DrawOn(LayerBackground, square(NewPixel(Lime)))
+	win.Update()
+	if got := backend.Frame().GetPixel(5, 5); got != NewPixel(Red) {
+		t.Errorf("Expected UI layer to be on top, got %v", got)
+	}
+
+	// Layers can be added between the others, and hidden
+	win.AddLayer("overlay", 250)
+	win.DrawOn("overlay", square(NewPixel(Yellow)))
+	win.DrawOn(LayerUI, square(NewPixel(Red)))
+	win.Update()
+	if got := backend.Frame().GetPixel(5, 5); got != NewPixel(Yellow) {
+		t.Errorf("Expected added layer to be above the UI layer, got %v", got)
+	}
+	win.Layer("overlay").SetVisible(false)
+	win.DrawOn("overlay", square(NewPixel(Yellow)))
+	win.DrawOn(LayerUI, square(NewPixel(Cyan)))
+	win.Update()
+	if got := backend.Frame().GetPixel(5, 5); got != NewPixel(Cyan) {
+		t.Errorf("Expected hidden layer not to be drawn, got %v", got)
+	}
+
+	for name, f := range map[string]func(){
+		"unknown layer":   func() { win.DrawOn("missing") },
+		"duplicate layer": func() { win.AddLayer(LayerUI, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s to panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestLayerEffect(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 10, 10)
+
+	// The effect only sees the layer's own pixels, which are then blended over the others
+	var seen Pixel
+	win.Layer(LayerUI).SetEffect(effectFunc(func(buf *FrameBuffer) {
+		seen = buf.GetPixel(8, 8)
+		buf.SetPixelFunc(1, 1, NewPixel(Red), SrcBlend)
+	}))
+	win.SetBackground(Blue)
+	win.DrawOn(LayerUI, NewRect(2, 2, Vec{5, 5}).SetStyle(Style{Colour: White}))
+	win.Update()
+
+	if seen != 0 {
+		t.Errorf("Expected effect to see a transparent frame buffer, got %v", seen)
+	}
+	for _, tc := range []struct {
+		x, y int
+		want Pixel
+	}{
+		{5, 5, NewPixel(White)},
+		{1, 1, NewPixel(Red)},
+		{8, 8, NewPixel(Blue)},
+	} {
+		if got := backend.Frame().GetPixel(tc.x, tc.y); got != tc.want {
+			t.Errorf("Expected pixel (%d,%d) to be %v, got %v", tc.x, tc.y, tc.want, got)
+		}
+	}
+
+	// The layer is cleared between frames
+	win.Layer(LayerUI).SetEffect(effectFunc(func(buf *FrameBuffer) { seen = buf.GetPixel(5, 5) }))
+	win.Update()
+	if seen != 0 {
+		t.Errorf("Expected layer to be cleared, got %v", seen)
+	}
+}
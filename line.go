@@ -0,0 +1,654 @@
+package gogl
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// LineCap is the shape drawn at the ends of a line.
+type LineCap int
+
+const (
+	CapButt   LineCap = iota // end flat at the end points
+	CapRound                 // end with a semicircle around the end points
+	CapSquare                // end flat, half the line's thickness beyond the end points
+)
+
+// String returns the name of the line cap.
+func (c LineCap) String() string {
+	switch c {
+	case CapButt:
+		return "butt"
+	case CapRound:
+		return "round"
+	case CapSquare:
+		return "square"
+	default:
+		return "invalid"
+	}
+}
+
+// LineJoin is the shape drawn where two segments of a polyline meet.
+type LineJoin int
+
+const (
+	JoinMiter LineJoin = iota // extend the outer edges until they meet
+	JoinRound                 // round off the corner
+	JoinBevel                 // cut off the corner
+)
+
+// String returns the name of the line join.
+func (j LineJoin) String() string {
+	switch j {
+	case JoinMiter:
+		return "miter"
+	case JoinRound:
+		return "round"
+	case JoinBevel:
+		return "bevel"
+	default:
+		return "invalid"
+	}
+}
+
+// miterLimit is the longest a miter join can be, relative to half the line's thickness.
+// Sharper corners are bevelled instead.
+const miterLimit = 4
+
+// Line is a straight line between two points. The style's thickness sets the width of
+// the line, where 0 draws a line one pixel wide.
+type Line struct {
+	v1, v2 Vec
+	style  Style
+	cap    LineCap
+	transformable
+}
+
+var _ Shape = (*Line)(nil)
+
+// NewLine constructs a new line between two points.
+func NewLine(v1, v2 Vec) *Line {
+	return &Line{
+		v1:    v1,
+		v2:    v2,
+		style: DefaultStyle,
+		cap:   CapButt,
+	}
+}
+
+// Points returns the start and end points of the line.
+func (l *Line) Points() (Vec, Vec) {
+	return l.v1, l.v2
+}
+
+// SetPoints sets the start and end points of the line.
+func (l *Line) SetPoints(v1, v2 Vec) *Line {
+	l.v1, l.v2 = v1, v2
+	return l
+}
+
+// Cap returns the shape drawn at the ends of the line.
+func (l *Line) Cap() LineCap {
+	return l.cap
+}
+
+// SetCap sets the shape drawn at the ends of the line.
+func (l *Line) SetCap(c LineCap) *Line {
+	l.cap = c
+	return l
+}
+
+// Width returns the horizontal distance between the ends of the line.
+func (l *Line) Width() float64 {
+	return math.Abs(l.v2.X - l.v1.X)
+}
+
+// Height returns the vertical distance between the ends of the line.
+func (l *Line) Height() float64 {
+	return math.Abs(l.v2.Y - l.v1.Y)
+}
+
+// GetPos returns the start point of the line.
+func (l *Line) GetPos() Vec {
+	return l.v1
+}
+
+// SetPos moves the line so that it starts at the given position.
+func (l *Line) SetPos(pos Vec) {
+	l.Move(Sub(pos, l.v1))
+}
+
+// GetStyle returns the line's style.
+func (l *Line) GetStyle() Style {
+	return l.style
+}
+
+// SetStyle sets the style of the line.
+func (l *Line) SetStyle(style Style) *Line {
+	l.style = style
+	return l
+}
+
+// Move moves the line by the given vector.
+func (l *Line) Move(px Vec) {
+	l.v1 = Add(l.v1, px)
+	l.v2 = Add(l.v2, px)
+}
+
+// String returns the type of shape as a string.
+func (l *Line) String() string {
+	return "line"
+}
+
+// Bounds returns the pixel bounding box of the line, including its caps.
+func (l *Line) Bounds() image.Rectangle {
+	if l.transformed {
+		return l.applyTransform().Bounds()
+	}
+	return strokeBounds([]Vec{l.v1, l.v2}, l.style, 2)
+}
+
+// SVG returns the line as an SVG element.
+func (l *Line) SVG() string {
+	if l.transformed {
+		return l.applyTransform().SVG()
+	}
+	return fmt.Sprintf(`<line x1="%s" y1="%s" x2="%s" y2="%s"%s%s/>`,
+		svgNum(l.v1.X), svgNum(l.v1.Y), svgNum(l.v2.X), svgNum(l.v2.Y),
+		svgPaint(l.style, max(l.style.Thickness, 1)), svgStroke(l.cap, JoinMiter))
+}
+
+// Draw draws the line onto the provided frame buffer.
+func (l *Line) Draw(buf *FrameBuffer) {
+	if l.transformed {
+		l.applyTransform().Draw(buf)
+		return
+	}
+
+	area := l.Bounds().Intersect(buf.clip)
+	if area.Empty() {
+		return
+	}
+
+	// Thin lines are stepped along pixel by pixel. They cover each pixel once, so they are
+	// plotted straight onto the frame buffer.
+	b := newBrush(buf, l.style)
+	switch thin := l.style.Thickness <= 1; {
+	case thin && l.style.AntiAlias:
+		wuLine(l.v1, l.v2, b.cover)
+		return
+	case thin:
+		bresenhamLine(l.v1, l.v2, b.cover)
+		return
+	}
+
+	mask := newCoverageMask(area)
+	halfWidth := l.style.Thickness / 2
+	mask.addDistance(area, l.style.AntiAlias, func(p Vec) float64 {
+		return segmentDist(p, l.v1, l.v2, halfWidth, l.cap, l.cap)
+	})
+	mask.draw(b)
+}
+
+// SetTransform sets a transform which is applied about the start of the line. The
+// line's thickness is scaled by the transform's average scale factor.
+func (l *Line) SetTransform(t Transform) *Line {
+	l.setTransform(t)
+	return l
+}
+
+// viewed returns a copy of the line with a view transform applied after its own,
+// and its opacity scaled.
+func (l *Line) viewed(view Transform, opacity float64) Drawable {
+	v := *l
+	v.transformable = l.withView(view, l.v1)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// applyTransform returns a copy of the line with its transform applied to its points.
+func (l *Line) applyTransform() *Line {
+	m := l.matrix(l.v1)
+	return NewLine(m.Apply(l.v1), m.Apply(l.v2)).SetStyle(transformedStyle(l.style, m)).SetCap(l.cap)
+}
+
+// Polyline is a series of connected straight lines, such as a graph or a trail. The
+// style's thickness sets the width of the lines, where 0 draws lines one pixel wide.
+type Polyline struct {
+	points []Vec
+	style  Style
+	cap    LineCap
+	join   LineJoin
+	transformable
+}
+
+var _ Shape = (*Polyline)(nil)
+
+// NewPolyline constructs a new polyline through the given points.
+func NewPolyline(points []Vec) *Polyline {
+	return &Polyline{
+		points: points,
+		style:  DefaultStyle,
+		cap:    CapButt,
+		join:   JoinMiter,
+	}
+}
+
+// Points returns the points which the polyline passes through.
+func (p *Polyline) Points() []Vec {
+	return p.points
+}
+
+// SetPoints sets the points which the polyline passes through.
+func (p *Polyline) SetPoints(points []Vec) *Polyline {
+	p.points = points
+	return p
+}
+
+// Append adds points to the end of the polyline.
+func (p *Polyline) Append(points ...Vec) *Polyline {
+	p.points = append(p.points, points...)
+	return p
+}
+
+// Cap returns the shape drawn at the ends of the polyline.
+func (p *Polyline) Cap() LineCap {
+	return p.cap
+}
+
+// SetCap sets the shape drawn at the ends of the polyline.
+func (p *Polyline) SetCap(c LineCap) *Polyline {
+	p.cap = c
+	return p
+}
+
+// Join returns the shape drawn where the segments of the polyline meet.
+func (p *Polyline) Join() LineJoin {
+	return p.join
+}
+
+// SetJoin sets the shape drawn where the segments of the polyline meet.
+func (p *Polyline) SetJoin(j LineJoin) *Polyline {
+	p.join = j
+	return p
+}
+
+// Width returns the width of the box bounding the polyline's points.
+func (p *Polyline) Width() float64 {
+	minV, maxV := pointExtent(p.points)
+	return maxV.X - minV.X
+}
+
+// Height returns the height of the box bounding the polyline's points.
+func (p *Polyline) Height() float64 {
+	minV, maxV := pointExtent(p.points)
+	return maxV.Y - minV.Y
+}
+
+// GetPos returns the first point of the polyline.
+func (p *Polyline) GetPos() Vec {
+	if len(p.points) == 0 {
+		return Vec{}
+	}
+	return p.points[0]
+}
+
+// SetPos moves the polyline so that it starts at the given position.
+func (p *Polyline) SetPos(pos Vec) {
+	p.Move(Sub(pos, p.GetPos()))
+}
+
+// GetStyle returns the polyline's style.
+func (p *Polyline) GetStyle() Style {
+	return p.style
+}
+
+// SetStyle sets the style of the polyline.
+func (p *Polyline) SetStyle(style Style) *Polyline {
+	p.style = style
+	return p
+}
+
+// Move moves the polyline by the given vector.
+func (p *Polyline) Move(px Vec) {
+	for i := range p.points {
+		p.points[i] = Add(p.points[i], px)
+	}
+}
+
+// String returns the type of shape as a string.
+func (p *Polyline) String() string {
+	return "polyline"
+}
+
+// Bounds returns the pixel bounding box of the polyline, including its caps and joins.
+func (p *Polyline) Bounds() image.Rectangle {
+	if p.transformed {
+		return p.applyTransform().Bounds()
+	}
+	return strokeBounds(p.points, p.style, miterLimit)
+}
+
+// SVG returns the polyline as an SVG element.
+func (p *Polyline) SVG() string {
+	if p.transformed {
+		return p.applyTransform().SVG()
+	}
+	return fmt.Sprintf(`<polyline points="%s"%s%s/>`,
+		svgPoints(p.points), svgPaint(p.style, max(p.style.Thickness, 1)), svgStroke(p.cap, p.join))
+}
+
+// Draw draws the polyline onto the provided frame buffer. Overlapping segments are
+// only drawn once, so translucent polylines have an even colour.
+func (p *Polyline) Draw(buf *FrameBuffer) {
+	if len(p.points) < 2 {
+		return
+	}
+	if p.transformed {
+		p.applyTransform().Draw(buf)
+		return
+	}
+	area := p.Bounds().Intersect(buf.clip)
+	if area.Empty() {
+		return
+	}
+
+	mask := newCoverageMask(area)
+	mask.addStroke(p.points, false, p.style, p.cap, p.join)
+	mask.draw(newBrush(buf, p.style))
+}
+
+// SetTransform sets a transform which is applied about the first point of the polyline.
+// The polyline's thickness is scaled by the transform's average scale factor.
+func (p *Polyline) SetTransform(t Transform) *Polyline {
+	p.setTransform(t)
+	return p
+}
+
+// viewed returns a copy of the polyline with a view transform applied after its own,
+// and its opacity scaled.
+func (p *Polyline) viewed(view Transform, opacity float64) Drawable {
+	v := *p
+	v.transformable = p.withView(view, p.GetPos())
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// applyTransform returns a copy of the polyline with its transform applied to its
+// points.
+func (p *Polyline) applyTransform() *Polyline {
+	m := p.matrix(p.GetPos())
+	return NewPolyline(transformPoints(p.points, m)).
+		SetStyle(transformedStyle(p.style, m)).
+		SetCap(p.cap).
+		SetJoin(p.join)
+}
+
+// addStroke adds lines through the points to the mask, with caps at the ends and joins
+// where the lines meet. Closed strokes also join the last point back to the first, and
+// have no caps.
+func (m *coverageMask) addStroke(points []Vec, closed bool, style Style, cap LineCap, join LineJoin) {
+	if len(points) < 2 {
+		return
+	}
+	halfWidth := max(style.Thickness, 1) / 2
+	thin := style.Thickness <= 1
+
+	last := len(points) - 2
+	if closed {
+		last++
+	}
+	for i := range last + 1 {
+		a, b := points[i], points[(i+1)%len(points)]
+
+		// Only the ends of open strokes get caps. Round joins are made by rounding off
+		// the ends of the segments, and other joins are filled in separately.
+		capA, capB := CapButt, CapButt
+		if join == JoinRound {
+			capA, capB = CapRound, CapRound
+		}
+		if i == 0 && !closed {
+			capA = cap
+		}
+		if i == last && !closed {
+			capB = cap
+		}
+
+		segment := func(pt Vec) float64 {
+			return segmentDist(pt, a, b, halfWidth, capA, capB)
+		}
+		bounds := strokeBounds([]Vec{a, b}, style, 2)
+		switch {
+		case thin && style.AntiAlias:
+			wuLine(a, b, m.set)
+		case thin:
+			bresenhamLine(a, b, m.set)
+		default:
+			m.addDistance(bounds, style.AntiAlias, segment)
+		}
+
+		if (i < last || closed) && !thin && join != JoinRound {
+			corner := joinPolygon(a, b, points[(i+2)%len(points)], halfWidth, join)
+			if corner == nil {
+				continue
+			}
+			cornerDist := func(pt Vec) float64 {
+				return convexDist(pt, corner)
+			}
+			minV, maxV := pointExtent(corner)
+			bounds := pixelBounds(minV.X, minV.Y, maxV.X, maxV.Y)
+			m.addDistance(bounds, style.AntiAlias, cornerDist)
+		}
+	}
+}
+
+// strokeBounds returns the pixel bounding box of lines through the points, allowing for
+// caps and joins which extend up to reach times half the line's thickness beyond them.
+func strokeBounds(points []Vec, style Style, reach float64) image.Rectangle {
+	if len(points) == 0 {
+		return image.Rectangle{}
+	}
+	minV, maxV := pointExtent(points)
+	margin := max(style.Thickness, 1) / 2 * reach
+	return pixelBounds(minV.X-margin, minV.Y-margin, maxV.X+margin, maxV.Y+margin)
+}
+
+// pointExtent returns the minimum and maximum coordinates of a set of points.
+func pointExtent(points []Vec) (Vec, Vec) {
+	if len(points) == 0 {
+		return Vec{}, Vec{}
+	}
+	minV, maxV := points[0], points[0]
+	for _, v := range points[1:] {
+		minV = Vec{math.Min(minV.X, v.X), math.Min(minV.Y, v.Y)}
+		maxV = Vec{math.Max(maxV.X, v.X), math.Max(maxV.Y, v.Y)}
+	}
+	return minV, maxV
+}
+
+// segmentDist returns the signed distance from point p to the edge of a line segment
+// from a to b, which is positive inside the segment.
+func segmentDist(p, a, b Vec, halfWidth float64, capA, capB LineCap) float64 {
+	dist := math.Inf(-1)
+	if capA == CapRound {
+		dist = math.Max(dist, halfWidth-Dist(p, a))
+	}
+	if capB == CapRound {
+		dist = math.Max(dist, halfWidth-Dist(p, b))
+	}
+
+	length := Dist(a, b)
+	if length == 0 {
+		if capA == CapSquare {
+			// A zero length square cap is a square around the point
+			dist = math.Max(dist, halfWidth-math.Max(math.Abs(p.X-a.X), math.Abs(p.Y-a.Y)))
+		}
+		return dist
+	}
+
+	// Find the coordinates of p along and across the segment, then measure the
+	// distance to the rectangle which the segment covers
+	dir := Vec{(b.X - a.X) / length, (b.Y - a.Y) / length}
+	along := (p.X-a.X)*dir.X + (p.Y-a.Y)*dir.Y
+	across := (p.X-a.X)*dir.Y - (p.Y-a.Y)*dir.X
+
+	start, end := 0.0, length
+	if capA == CapSquare {
+		start -= halfWidth
+	}
+	if capB == CapSquare {
+		end += halfWidth
+	}
+	centre := (start + end) / 2
+	qx := math.Abs(along-centre) - (end-start)/2
+	qy := math.Abs(across) - halfWidth
+	outside := math.Hypot(math.Max(qx, 0), math.Max(qy, 0)) + math.Min(math.Max(qx, qy), 0)
+
+	return math.Max(dist, -outside)
+}
+
+// joinPolygon returns the convex polygon which fills the outer corner where segments
+// ab and bc meet, or nil if they don't turn.
+func joinPolygon(a, b, c Vec, halfWidth float64, join LineJoin) []Vec {
+	d1, d2 := Sub(b, a), Sub(c, b)
+	len1, len2 := math.Hypot(d1.X, d1.Y), math.Hypot(d2.X, d2.Y)
+	turn := Cross(d1, d2)
+	if len1 == 0 || len2 == 0 || turn == 0 {
+		return nil
+	}
+
+	// Find the outer edge of each segment at the corner
+	side := halfWidth
+	if turn > 0 {
+		side = -halfWidth
+	}
+	n1 := Vec{-d1.Y / len1 * side, d1.X / len1 * side}
+	n2 := Vec{-d2.Y / len2 * side, d2.X / len2 * side}
+	p1, p2 := Add(b, n1), Add(b, n2)
+
+	if join == JoinMiter {
+		// The miter tip lies along the bisector of the outer edges, at half the
+		// thickness divided by the cosine of half the angle between them
+		bisector := Add(n1, n2)
+		if lenSq := bisector.X*bisector.X + bisector.Y*bisector.Y; lenSq > 0 {
+			scale := 2 * halfWidth * halfWidth / lenSq
+			tip := Add(b, Vec{bisector.X * scale, bisector.Y * scale})
+			if Dist(tip, b) <= miterLimit*halfWidth {
+				return []Vec{b, p1, tip, p2}
+			}
+		}
+	}
+	return []Vec{b, p1, p2}
+}
+
+// convexDist returns the signed distance from point p to the edge of a convex polygon,
+// which is positive inside the polygon. Outside the polygon, the distance is
+// underestimated near the vertices.
+func convexDist(p Vec, vertices []Vec) float64 {
+	// The sign of the area gives the winding direction of the vertices
+	area := 0.0
+	for i := range vertices {
+		area += Cross(vertices[i], vertices[(i+1)%len(vertices)])
+	}
+
+	dist := math.Inf(1)
+	for i := range vertices {
+		a, b := vertices[i], vertices[(i+1)%len(vertices)]
+		length := Dist(a, b)
+		if length == 0 {
+			continue
+		}
+		d := edgeFunction(a, b, p) / length
+		if area < 0 {
+			d = -d
+		}
+		dist = math.Min(dist, d)
+	}
+	return dist
+}
+
+// bresenhamLine calls plot with full coverage for each pixel on a line between two
+// points, using Bresenham's line drawing algorithm.
+func bresenhamLine(v1, v2 Vec, plot func(x, y int, coverage float64)) {
+	dx := math.Abs(v2.X - v1.X)
+	sx := 1
+	if v1.X > v2.X {
+		sx = -1
+	}
+
+	dy := -math.Abs(v2.Y - v1.Y)
+	sy := 1
+	if v1.Y > v2.Y {
+		sy = -1
+	}
+
+	err := dx + dy
+	x1, y1 := int(math.Round(v1.X)), int(math.Round(v1.Y))
+	x2, y2 := int(math.Round(v2.X)), int(math.Round(v2.Y))
+
+	for {
+		plot(x1, y1, 1)
+		if x1 == x2 && y1 == y2 {
+			break
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+// wuLine calls plot for each pixel on an anti-aliased line between two points, using
+// Xiaolin Wu's line algorithm. Each step along the line covers the two pixels nearest
+// to it, in proportion to how close they are. Each pixel is plotted at most once.
+func wuLine(v1, v2 Vec, plot func(x, y int, coverage float64)) {
+	steep := math.Abs(v2.Y-v1.Y) > math.Abs(v2.X-v1.X)
+	if steep {
+		// Step along y instead, swapping the coordinates back when plotting
+		v1, v2 = Vec{v1.Y, v1.X}, Vec{v2.Y, v2.X}
+		original := plot
+		plot = func(x, y int, coverage float64) {
+			original(y, x, coverage)
+		}
+	}
+	if v1.X > v2.X {
+		v1, v2 = v2, v1
+	}
+
+	gradient := 1.0
+	if dx := v2.X - v1.X; dx != 0 {
+		gradient = (v2.Y - v1.Y) / dx
+	}
+	fpart := func(v float64) float64 {
+		return v - math.Floor(v)
+	}
+
+	// Each end point is weighted by how much of its pixel the line reaches into
+	endPoint := func(v Vec, gap float64) (int, float64) {
+		x := math.Round(v.X)
+		y := v.Y + gradient*(x-v.X)
+		plot(int(x), int(math.Floor(y)), (1-fpart(y))*gap)
+		plot(int(x), int(math.Floor(y))+1, fpart(y)*gap)
+		return int(x), y
+	}
+	if math.Round(v1.X) == math.Round(v2.X) {
+		// Both end points fall in the same pixels, so they are plotted together
+		endPoint(v1, max(1-fpart(v1.X+0.5), fpart(v2.X+0.5)))
+		return
+	}
+	x1, y := endPoint(v1, 1-fpart(v1.X+0.5))
+	x2, _ := endPoint(v2, fpart(v2.X+0.5))
+
+	for x := x1 + 1; x < x2; x++ {
+		y += gradient
+		plot(x, int(math.Floor(y)), 1-fpart(y))
+		plot(x, int(math.Floor(y))+1, fpart(y))
+	}
+}
@@ -1,7 +1,5 @@
 package gogl
 
-import "github.com/jupiterrider/purego-sdl3/sdl"
-
 // MouseScrollCallback is executed when the user scrolls the mouse wheel in any direction.
 //
 // Positive X movement means scrolling to the right. Positive Y movement means scrolling up.
@@ -40,6 +38,6 @@ func newMouseScrollHandler() *mouseScrollHandler {
 }
 
 // handleEvent handles a mouse scroll event.
-func (m *mouseScrollHandler) handleEvent(event sdl.MouseWheelEvent) {
-	m.Callback(Vec{float64(event.MouseX), float64(event.MouseY)})
+func (m *mouseScrollHandler) handleEvent(event MouseWheelEvent) {
+	m.Callback(event.Movement)
 }
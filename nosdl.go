@@ -0,0 +1,57 @@
+//go:build nosdl
+
+package gogl
+
+import (
+	"errors"
+	"image"
+)
+
+// errNoSDL is returned when a window is created without a backend, but gogl was built
+// with the nosdl tag.
+var errNoSDL = errors.New("failed to init sdl3: gogl was built with the nosdl tag, so the window needs a Backend")
+
+// sdlBackend stands in for the SDL backend when gogl is built with the nosdl tag, so that
+// programs which only use other backends don't need the SDL libraries. It can't be
+// initialised, and does nothing if it is used anyway.
+type sdlBackend struct{}
+
+var _ Backend = (*sdlBackend)(nil)
+
+// newSDLBackend constructs a backend which fails to initialise.
+func newSDLBackend() *sdlBackend {
+	return &sdlBackend{}
+}
+
+// Init returns an error, as SDL isn't available.
+func (s *sdlBackend) Init(WindowCfg) error {
+	return errNoSDL
+}
+
+// Destroy implements Backend. There is nothing to release.
+func (s *sdlBackend) Destroy() {}
+
+// PollEvent implements Backend. There are never any events.
+func (s *sdlBackend) PollEvent() (Event, bool) {
+	return nil, false
+}
+
+// Present returns an error, as SDL isn't available.
+func (s *sdlBackend) Present(*FrameBuffer, []image.Rectangle) error {
+	return errNoSDL
+}
+
+// Mouse implements Backend. The mouse is always at the origin with no buttons pressed.
+func (s *sdlBackend) Mouse() (Vec, MouseState) {
+	return Vec{}, NoClick
+}
+
+// Size implements Backend. There is no window, so its size is zero.
+func (s *sdlBackend) Size() (int, int) {
+	return 0, 0
+}
+
+// SetTitle returns an error, as SDL isn't available.
+func (s *sdlBackend) SetTitle(string) error {
+	return errNoSDL
+}
@@ -0,0 +1,32 @@
+//go:build nosdl
+
+package gogl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoSDL(t *testing.T) {
+	if _, err := NewWindow(WindowCfg{Width: 10, Height: 10}); !errors.Is(err, errNoSDL) {
+		t.Errorf("Expected window without a backend to fail, got %v", err)
+	}
+	if _, err := NewWindow(WindowCfg{Width: 10, Height: 10, Backend: NewHeadlessBackend()}); err != nil {
+		t.Errorf("Expected headless window to work without SDL, got %v", err)
+	}
+
+	// The stand-in backend is safe to use, even though it can't be initialised
+	b := newSDLBackend()
+	if _, ok := b.PollEvent(); ok {
+		t.Error("Expected no events")
+	}
+	if err := b.Present(NewFrameBuffer(1, 1), nil); !errors.Is(err, errNoSDL) {
+		t.Errorf("Expected present to fail, got %v", err)
+	}
+	if err := b.SetTitle("title"); !errors.Is(err, errNoSDL) {
+		t.Errorf("Expected setting the title to fail, got %v", err)
+	}
+	b.Mouse()
+	b.Size()
+	b.Destroy()
+}
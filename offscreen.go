@@ -0,0 +1,40 @@
+package gogl
+
+import "image"
+
+// offscreen is a transparent frame buffer which is drawn onto before being composited
+// onto a target. It is kept between frames so that it isn't reallocated every time.
+type offscreen struct {
+	buf *FrameBuffer
+}
+
+// draw draws onto the offscreen frame buffer, then composites the region that was drawn
+// on onto the target. The region is cleared afterwards, ready for the next frame.
+func (o *offscreen) draw(
+	target *FrameBuffer,
+	draw func(off *FrameBuffer),
+	composite func(off *FrameBuffer, area image.Rectangle),
+) {
+	off := o.buf
+	if off == nil || off.width != target.width || off.height != target.height ||
+		off.premultiplied != target.premultiplied {
+		off = NewFrameBuffer(target.width, target.height)
+		if target.premultiplied {
+			off = NewPremultipliedFrameBuffer(target.width, target.height)
+		}
+		o.buf = off
+	}
+	off.ClearDirty()
+	draw(off)
+
+	// Only the region that was drawn on needs to be composited and cleared
+	area := off.dirtyBounds()
+	if area.Empty() {
+		return
+	}
+	composite(off, area)
+
+	off.PushClip(area)
+	off.Clear()
+	off.PopClip()
+}
@@ -0,0 +1,257 @@
+package gogl
+
+import (
+	"image/color"
+	"math"
+)
+
+// Paint gives the colour of each pixel of a shape. Points are in frame buffer
+// coordinates, so a paint stays in place when the shapes it colours are moved.
+type Paint interface {
+	// At returns the colour of the paint at a point.
+	At(p Vec) color.Color
+}
+
+// paintSampler is a paint which can prepare a faster function for sampling its colour
+// at each pixel of a shape, which returns a pixel with straight alpha.
+type paintSampler interface {
+	sampler() func(p Vec) Pixel
+}
+
+// samplePaint returns a function for sampling a paint's colour.
+func samplePaint(paint Paint) func(p Vec) Pixel {
+	if s, ok := paint.(paintSampler); ok {
+		return s.sampler()
+	}
+	return func(p Vec) Pixel {
+		return NewPixel(paint.At(p))
+	}
+}
+
+// SolidPaint paints every pixel the same colour.
+type SolidPaint struct {
+	Colour color.Color
+}
+
+var _ Paint = SolidPaint{}
+
+// At implements Paint.
+func (s SolidPaint) At(Vec) color.Color {
+	return s.Colour
+}
+
+// sampler implements paintSampler.
+func (s SolidPaint) sampler() func(p Vec) Pixel {
+	pixel := NewPixel(s.Colour)
+	return func(Vec) Pixel {
+		return pixel
+	}
+}
+
+// ColourStop is a colour at a position along a gradient, from 0 at the start to 1 at
+// the end. Colours between stops are blended smoothly.
+type ColourStop struct {
+	Offset float64
+	Colour color.Color
+}
+
+// SpreadMode decides how a gradient or pattern fills the space beyond its end.
+type SpreadMode int
+
+const (
+	SpreadPad     SpreadMode = iota // continue the colour at the end
+	SpreadRepeat                    // start again from the beginning
+	SpreadReflect                   // run backwards and forwards
+)
+
+// String returns the name of the spread mode.
+func (s SpreadMode) String() string {
+	switch s {
+	case SpreadPad:
+		return "pad"
+	case SpreadRepeat:
+		return "repeat"
+	case SpreadReflect:
+		return "reflect"
+	default:
+		return "invalid"
+	}
+}
+
+// apply maps a position along a gradient into the range 0 to 1.
+func (s SpreadMode) apply(t float64) float64 {
+	switch s {
+	case SpreadRepeat:
+		return t - math.Floor(t)
+	case SpreadReflect:
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+		return t
+	default:
+		return Clamp(t, 0, 1)
+	}
+}
+
+// LinearGradient blends between colours along a line from Start to End. The colour is
+// the same along lines perpendicular to it.
+type LinearGradient struct {
+	Start, End Vec
+	Stops      []ColourStop // in order of offset
+	Spread     SpreadMode
+}
+
+var _ Paint = LinearGradient{}
+
+// At implements Paint.
+func (g LinearGradient) At(p Vec) color.Color {
+	return stopColour(g.Stops, g.Spread.apply(g.position(p)))
+}
+
+// sampler implements paintSampler.
+func (g LinearGradient) sampler() func(p Vec) Pixel {
+	return gradientSampler(g.Stops, g.Spread, g.position)
+}
+
+// position returns how far along the gradient a point is.
+func (g LinearGradient) position(p Vec) float64 {
+	d := Sub(g.End, g.Start)
+	lenSq := d.X*d.X + d.Y*d.Y
+	if lenSq == 0 {
+		return 0
+	}
+	return ((p.X-g.Start.X)*d.X + (p.Y-g.Start.Y)*d.Y) / lenSq
+}
+
+// RadialGradient blends between colours outwards from Centre, reaching the end of the
+// gradient at Radius. The colour is the same around circles about the centre.
+type RadialGradient struct {
+	Centre Vec
+	Radius float64
+	Stops  []ColourStop // in order of offset
+	Spread SpreadMode
+}
+
+var _ Paint = RadialGradient{}
+
+// At implements Paint.
+func (g RadialGradient) At(p Vec) color.Color {
+	return stopColour(g.Stops, g.Spread.apply(g.position(p)))
+}
+
+// sampler implements paintSampler.
+func (g RadialGradient) sampler() func(p Vec) Pixel {
+	return gradientSampler(g.Stops, g.Spread, g.position)
+}
+
+// position returns how far along the gradient a point is.
+func (g RadialGradient) position(p Vec) float64 {
+	if g.Radius == 0 {
+		return 1
+	}
+	return Dist(p, g.Centre) / g.Radius
+}
+
+// ConicGradient blends between colours around Centre, starting at Angle radians
+// clockwise from the positive x axis and going once round. The colour is the same
+// along lines out from the centre.
+type ConicGradient struct {
+	Centre Vec
+	Angle  float64
+	Stops  []ColourStop // in order of offset
+}
+
+var _ Paint = ConicGradient{}
+
+// At implements Paint.
+func (g ConicGradient) At(p Vec) color.Color {
+	return stopColour(g.Stops, g.position(p))
+}
+
+// sampler implements paintSampler.
+func (g ConicGradient) sampler() func(p Vec) Pixel {
+	return gradientSampler(g.Stops, SpreadPad, g.position)
+}
+
+// position returns how far around the gradient a point is.
+func (g ConicGradient) position(p Vec) float64 {
+	turns := (math.Atan2(p.Y-g.Centre.Y, p.X-g.Centre.X) - g.Angle) / (2 * math.Pi)
+	return turns - math.Floor(turns)
+}
+
+// ImagePattern paints with the pixels of an image, which has its top left corner at
+// Offset. Spread decides how the image fills the space beyond its edges.
+type ImagePattern struct {
+	Image  *FrameBuffer
+	Offset Vec
+	Spread SpreadMode
+}
+
+var _ Paint = ImagePattern{}
+
+// At implements Paint.
+func (i ImagePattern) At(p Vec) color.Color {
+	return i.sampler()(p)
+}
+
+// sampler implements paintSampler.
+func (i ImagePattern) sampler() func(p Vec) Pixel {
+	width, height := float64(i.Image.width), float64(i.Image.height)
+	coord := func(v, size float64) int {
+		// Spread the image's pixels across the range 0 to 1
+		t := i.Spread.apply(math.Floor(v)/size + 0.5/size)
+		return min(int(t*size), int(size)-1)
+	}
+	return func(p Vec) Pixel {
+		return i.Image.GetPixel(coord(p.X-i.Offset.X, width), coord(p.Y-i.Offset.Y, height))
+	}
+}
+
+// stopColour returns the colour at a position along a gradient.
+func stopColour(stops []ColourStop, t float64) Pixel {
+	switch {
+	case len(stops) == 0:
+		return 0
+	case t <= stops[0].Offset:
+		return NewPixel(stops[0].Colour)
+	case t >= stops[len(stops)-1].Offset:
+		return NewPixel(stops[len(stops)-1].Colour)
+	}
+
+	i := 1
+	for stops[i].Offset < t {
+		i++
+	}
+	a, b := stops[i-1], stops[i]
+	if b.Offset == a.Offset {
+		return NewPixel(b.Colour)
+	}
+	return lerpPixel(NewPixel(a.Colour), NewPixel(b.Colour), (t-a.Offset)/(b.Offset-a.Offset))
+}
+
+// lerpPixel blends linearly between two pixels with straight alpha. The colours are
+// weighted by their alpha, so fading to a transparent colour doesn't darken the
+// colour in between.
+func lerpPixel(a, b Pixel, t float64) Pixel {
+	a, b = a.Premultiply(), b.Premultiply()
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return pack(lerp(a.A(), b.A()), lerp(a.B(), b.B()), lerp(a.G(), b.G()), lerp(a.R(), b.R())).Unpremultiply()
+}
+
+// gradientLUTSize is the number of colours which gradients are sampled from.
+const gradientLUTSize = 256
+
+// gradientSampler returns a function for sampling a gradient, which looks up its colours
+// in a table rather than blending the stops at every pixel.
+func gradientSampler(stops []ColourStop, spread SpreadMode, position func(p Vec) float64) func(p Vec) Pixel {
+	var lut [gradientLUTSize]Pixel
+	for i := range lut {
+		lut[i] = stopColour(stops, float64(i)/(gradientLUTSize-1))
+	}
+	return func(p Vec) Pixel {
+		return lut[int(spread.apply(position(p))*(gradientLUTSize-1)+0.5)]
+	}
+}
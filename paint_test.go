@@ -0,0 +1,113 @@
+package gogl
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestSpreadMode(t *testing.T) {
+	for _, tc := range []struct {
+		spread SpreadMode
+		t      float64
+		want   float64
+	}{
+		{SpreadPad, -0.5, 0},
+		{SpreadPad, 0.25, 0.25},
+		{SpreadPad, 1.5, 1},
+		{SpreadRepeat, 1.25, 0.25},
+		{SpreadRepeat, -0.25, 0.75},
+		{SpreadReflect, 1.25, 0.75},
+		{SpreadReflect, -0.25, 0.25},
+		{SpreadReflect, 2.25, 0.25},
+	} {
+		if got := tc.spread.apply(tc.t); got != tc.want {
+			t.Errorf("%v spread of %v: expected %v, got %v", tc.spread, tc.t, tc.want, got)
+		}
+	}
+}
+
+func TestLinearGradient(t *testing.T) {
+	g := LinearGradient{
+		Start: Vec{0, 0},
+		End:   Vec{10, 0},
+		Stops: []ColourStop{{0, Black}, {0.5, White}, {1, color.RGBA{0, 0, 0, 0}}},
+	}
+
+	for _, tc := range []struct {
+		p    Vec
+		want Pixel
+	}{
+		{Vec{-5, 3}, NewPixel(Black)},
+		{Vec{2.5, 7}, NewPixel(color.RGBA{128, 128, 128, 255})},
+		{Vec{5, 0}, NewPixel(White)},
+		// Fading to transparent keeps the colour
+		{Vec{7.5, 0}, NewPixel(color.RGBA{255, 255, 255, 128})},
+		{Vec{20, 0}, 0},
+	} {
+		if got := NewPixel(g.At(tc.p)); got != tc.want {
+			t.Errorf("Linear gradient at %v: expected %v, got %v", tc.p, tc.want, got)
+		}
+	}
+}
+
+func TestRadialAndConicGradients(t *testing.T) {
+	stops := []ColourStop{{0, Red}, {1, Blue}}
+
+	radial := RadialGradient{Centre: Vec{10, 10}, Radius: 5, Stops: stops, Spread: SpreadRepeat}
+	if got := NewPixel(radial.At(Vec{10, 10})); got != NewPixel(Red) {
+		t.Errorf("Expected radial gradient to start red at its centre, got %v", got)
+	}
+	if got := NewPixel(radial.At(Vec{10, 4})); got != NewPixel(radial.At(Vec{10, 9})) {
+		t.Errorf("Expected repeating radial gradient to repeat every radius")
+	}
+
+	conic := ConicGradient{Centre: Vec{0, 0}, Stops: stops}
+	if got := NewPixel(conic.At(Vec{1, 0})); got != NewPixel(Red) {
+		t.Errorf("Expected conic gradient to start red along the x axis, got %v", got)
+	}
+	if got := NewPixel(conic.At(Vec{-1, 0})); got != NewPixel(color.RGBA{128, 0, 128, 255}) {
+		t.Errorf("Expected conic gradient to be half way opposite its start, got %v", got)
+	}
+}
+
+func TestImagePattern(t *testing.T) {
+	img := NewFrameBuffer(2, 1)
+	img.SetPixel(0, 0, NewPixel(Red))
+	img.SetPixel(1, 0, NewPixel(Blue))
+
+	for _, tc := range []struct {
+		spread SpreadMode
+		x      float64
+		want   color.Color
+	}{
+		{SpreadPad, 10, Red},
+		{SpreadPad, 13, Blue},
+		{SpreadPad, 20, Blue},
+		{SpreadRepeat, 14, Red},
+		{SpreadRepeat, 9, Blue},
+		{SpreadReflect, 12, Blue},
+		{SpreadReflect, 13, Red},
+	} {
+		pattern := ImagePattern{Image: img, Offset: Vec{10, 0}, Spread: tc.spread}
+		if got := NewPixel(pattern.At(Vec{tc.x, 0})); got != NewPixel(tc.want) {
+			t.Errorf("%v pattern at x=%v: expected %v, got %v", tc.spread, tc.x, NewPixel(tc.want), got)
+		}
+	}
+}
+
+func TestPaintDrawing(t *testing.T) {
+	paint := LinearGradient{Start: Vec{0, 0}, End: Vec{9, 0}, Stops: []ColourStop{{0, Red}, {1, Blue}}}
+
+	for _, f := range []*FrameBuffer{NewFrameBuffer(10, 10), NewPremultipliedFrameBuffer(10, 10)} {
+		NewRect(9, 9, Vec{0, 0}).SetStyle(Style{Paint: paint, Opacity: new(0.5)}).Draw(f)
+
+		// Each pixel is painted with the gradient's colour at that pixel
+		for _, x := range []int{0, 9} {
+			want := NewPixel(paint.At(Vec{float64(x), 5}))
+			want = want&^0xff | 128
+			if got := f.GetPixel(x, 5); got != want {
+				t.Errorf("Premultiplied %v: expected pixel %d to be %v, got %v", f.IsPremultiplied(), x, want, got)
+			}
+		}
+	}
+}
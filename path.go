@@ -0,0 +1,632 @@
+package gogl
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"strings"
+)
+
+// FillRule decides which parts of a path are inside it, where the path crosses over
+// itself or has holes.
+type FillRule int
+
+const (
+	FillNonZero FillRule = iota // inside where the path winds around a point at all
+	FillEvenOdd                 // inside where the path surrounds a point an odd number of times
+)
+
+// String returns the name of the fill rule.
+func (r FillRule) String() string {
+	switch r {
+	case FillNonZero:
+		return "nonzero"
+	case FillEvenOdd:
+		return "evenodd"
+	default:
+		return "invalid"
+	}
+}
+
+// isInside returns whether a point with the given winding number is inside the path.
+func (r FillRule) isInside(winding int) bool {
+	if r == FillEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// flattenTolerance is the furthest that the straight lines used to draw a curve can
+// stray from it, in pixels.
+const flattenTolerance = 0.1
+
+// fillSamples is the number of scanlines per row of pixels used to anti-alias fills.
+const fillSamples = 16
+
+// pathVerb is the kind of a path command.
+type pathVerb int
+
+const (
+	moveVerb pathVerb = iota
+	lineVerb
+	quadVerb
+	cubicVerb
+	closeVerb
+)
+
+// pathOp is a single path command. The points are the command's control points
+// followed by its end point.
+type pathOp struct {
+	verb   pathVerb
+	points [3]Vec
+}
+
+// end returns the point which the command finishes at.
+func (op pathOp) end() Vec {
+	switch op.verb {
+	case quadVerb:
+		return op.points[1]
+	case cubicVerb:
+		return op.points[2]
+	default:
+		return op.points[0]
+	}
+}
+
+// Path is a shape made from straight lines and curves. A path is made of subpaths,
+// which each start with MoveTo and may be closed with Close. Paths with thickness 0 are
+// filled according to their fill rule, with every subpath treated as closed. Otherwise,
+// the outline of the path is drawn with the style's thickness.
+type Path struct {
+	ops     []pathOp
+	start   Vec // start of the current subpath
+	current Vec // end of the last command
+	style   Style
+	rule    FillRule
+	cap     LineCap
+	join    LineJoin
+	transformable
+}
+
+var _ Shape = (*Path)(nil)
+
+// NewPath constructs a new empty path.
+func NewPath() *Path {
+	return &Path{
+		style: DefaultStyle,
+		rule:  FillNonZero,
+		cap:   CapButt,
+		join:  JoinMiter,
+	}
+}
+
+// MoveTo starts a new subpath at a point.
+func (p *Path) MoveTo(pt Vec) *Path {
+	p.ops = append(p.ops, pathOp{verb: moveVerb, points: [3]Vec{pt}})
+	p.start, p.current = pt, pt
+	return p
+}
+
+// LineTo adds a straight line from the current point to a point.
+func (p *Path) LineTo(pt Vec) *Path {
+	p.beginSubpath()
+	p.ops = append(p.ops, pathOp{verb: lineVerb, points: [3]Vec{pt}})
+	p.current = pt
+	return p
+}
+
+// QuadTo adds a quadratic Bézier curve from the current point to a point, bending
+// towards a control point.
+func (p *Path) QuadTo(ctrl, pt Vec) *Path {
+	p.beginSubpath()
+	p.ops = append(p.ops, pathOp{verb: quadVerb, points: [3]Vec{ctrl, pt}})
+	p.current = pt
+	return p
+}
+
+// CubicTo adds a cubic Bézier curve from the current point to a point, bending towards
+// two control points.
+func (p *Path) CubicTo(ctrl1, ctrl2, pt Vec) *Path {
+	p.beginSubpath()
+	p.ops = append(p.ops, pathOp{verb: cubicVerb, points: [3]Vec{ctrl1, ctrl2, pt}})
+	p.current = pt
+	return p
+}
+
+// ArcTo adds an elliptical arc from the current point to a point, in the same way as
+// the arc command of SVG paths. The ellipse has radii rx and ry, and is rotated
+// clockwise by rotation radians. Of the four arcs which fit, largeArc picks one which
+// spans more than 180 degrees, and sweep picks one which runs clockwise. If the radii
+// are too small to reach the point, they are scaled up until they do.
+func (p *Path) ArcTo(rx, ry, rotation float64, largeArc, sweep bool, pt Vec) *Path {
+	from := p.current
+	if from == pt {
+		return p
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return p.LineTo(pt)
+	}
+
+	// Find the centre of the ellipse, following the SVG specification's conversion from
+	// end points to centre parameters
+	sin, cos := math.Sincos(rotation)
+	hx, hy := (from.X-pt.X)/2, (from.Y-pt.Y)/2
+	x1 := cos*hx + sin*hy
+	y1 := -sin*hx + cos*hy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx, ry = rx*math.Sqrt(lambda), ry*math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(num/den, 0))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1, cy1 := coef*rx*y1/ry, -coef*ry*x1/rx
+	centre := Vec{
+		cos*cx1 - sin*cy1 + (from.X+pt.X)/2,
+		sin*cx1 + cos*cy1 + (from.Y+pt.Y)/2,
+	}
+
+	// Find the angles that the arc spans, on a unit circle
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	// Approximate the arc with a cubic curve for every quarter turn or less
+	ellipse := func(x, y float64) Vec {
+		return Vec{
+			centre.X + rx*cos*x - ry*sin*y,
+			centre.Y + rx*sin*x + ry*cos*y,
+		}
+	}
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	for i := range n {
+		s1, c1 := math.Sincos(theta + step*float64(i))
+		s2, c2 := math.Sincos(theta + step*float64(i+1))
+		end := ellipse(c2, s2)
+		if i == n-1 {
+			end = pt
+		}
+		p.CubicTo(ellipse(c1-k*s1, s1+k*c1), ellipse(c2+k*s2, s2-k*c2), end)
+	}
+	return p
+}
+
+// Close closes the current subpath with a straight line back to its start.
+func (p *Path) Close() *Path {
+	if len(p.ops) == 0 || p.ops[len(p.ops)-1].verb == closeVerb {
+		return p
+	}
+	p.ops = append(p.ops, pathOp{verb: closeVerb, points: [3]Vec{p.start}})
+	p.current = p.start
+	return p
+}
+
+// beginSubpath starts a new subpath at the current point, if there isn't one to add to.
+func (p *Path) beginSubpath() {
+	if len(p.ops) == 0 || p.ops[len(p.ops)-1].verb == closeVerb {
+		p.MoveTo(p.current)
+	}
+}
+
+// FillRule returns the rule which decides which parts of the path are filled.
+func (p *Path) FillRule() FillRule {
+	return p.rule
+}
+
+// SetFillRule sets the rule which decides which parts of the path are filled.
+func (p *Path) SetFillRule(rule FillRule) *Path {
+	p.rule = rule
+	return p
+}
+
+// Cap returns the shape drawn at the ends of the path's open subpaths.
+func (p *Path) Cap() LineCap {
+	return p.cap
+}
+
+// SetCap sets the shape drawn at the ends of the path's open subpaths.
+func (p *Path) SetCap(c LineCap) *Path {
+	p.cap = c
+	return p
+}
+
+// Join returns the shape drawn where the lines and curves of the path meet.
+func (p *Path) Join() LineJoin {
+	return p.join
+}
+
+// SetJoin sets the shape drawn where the lines and curves of the path meet.
+func (p *Path) SetJoin(j LineJoin) *Path {
+	p.join = j
+	return p
+}
+
+// Width returns the width of the box bounding the path.
+func (p *Path) Width() float64 {
+	minV, maxV := pointExtent(p.flatPoints())
+	return maxV.X - minV.X
+}
+
+// Height returns the height of the box bounding the path.
+func (p *Path) Height() float64 {
+	minV, maxV := pointExtent(p.flatPoints())
+	return maxV.Y - minV.Y
+}
+
+// GetPos returns the first point of the path.
+func (p *Path) GetPos() Vec {
+	if len(p.ops) == 0 {
+		return Vec{}
+	}
+	return p.ops[0].points[0]
+}
+
+// SetPos moves the path so that it starts at the given position.
+func (p *Path) SetPos(pos Vec) {
+	p.Move(Sub(pos, p.GetPos()))
+}
+
+// GetStyle returns the path's style.
+func (p *Path) GetStyle() Style {
+	return p.style
+}
+
+// SetStyle sets the style of the path.
+func (p *Path) SetStyle(style Style) *Path {
+	p.style = style
+	return p
+}
+
+// Move moves the path by the given vector.
+func (p *Path) Move(px Vec) {
+	for i := range p.ops {
+		for j := range p.ops[i].points {
+			p.ops[i].points[j] = Add(p.ops[i].points[j], px)
+		}
+	}
+	p.start, p.current = Add(p.start, px), Add(p.current, px)
+}
+
+// String returns the type of shape as a string.
+func (p *Path) String() string {
+	return "path"
+}
+
+// Bounds returns the pixel bounding box of the path, including any outline.
+// Curves lie within the box bounding their control points.
+func (p *Path) Bounds() image.Rectangle {
+	if p.transformed {
+		return p.applyTransform().Bounds()
+	}
+
+	var points []Vec
+	for _, op := range p.ops {
+		switch op.verb {
+		case quadVerb:
+			points = append(points, op.points[:2]...)
+		case cubicVerb:
+			points = append(points, op.points[:]...)
+		default:
+			points = append(points, op.points[0])
+		}
+	}
+	return strokeBounds(points, p.style, miterLimit)
+}
+
+// PathData returns the path as SVG path data, which ParsePathData can read.
+func (p *Path) PathData() string {
+	var parts []string
+	for _, op := range p.ops {
+		var points []Vec
+		switch op.verb {
+		case moveVerb:
+			parts, points = append(parts, "M"), op.points[:1]
+		case lineVerb:
+			parts, points = append(parts, "L"), op.points[:1]
+		case quadVerb:
+			parts, points = append(parts, "Q"), op.points[:2]
+		case cubicVerb:
+			parts, points = append(parts, "C"), op.points[:3]
+		case closeVerb:
+			parts = append(parts, "Z")
+		}
+		for _, pt := range points {
+			parts = append(parts, svgNum(pt.X), svgNum(pt.Y))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SVG returns the path as an SVG element.
+func (p *Path) SVG() string {
+	if p.transformed {
+		return p.applyTransform().SVG()
+	}
+	if p.style.Thickness > 0 {
+		return fmt.Sprintf(`<path d="%s"%s%s/>`,
+			p.PathData(), svgPaint(p.style, p.style.Thickness), svgStroke(p.cap, p.join))
+	}
+	var rule string
+	if p.rule == FillEvenOdd {
+		rule = ` fill-rule="evenodd"`
+	}
+	return fmt.Sprintf(`<path d="%s"%s%s/>`, p.PathData(), rule, svgPaint(p.style, 0))
+}
+
+// Draw draws the path onto the provided frame buffer.
+func (p *Path) Draw(buf *FrameBuffer) {
+	if p.transformed {
+		p.applyTransform().Draw(buf)
+		return
+	}
+
+	area := p.Bounds().Intersect(buf.clip)
+	if area.Empty() {
+		return
+	}
+
+	mask := newCoverageMask(area)
+	subpaths := p.flatten()
+	if p.style.Thickness > 0 {
+		for _, s := range subpaths {
+			mask.addStroke(s.points, s.closed, p.style, p.cap, p.join)
+		}
+	} else {
+		polygons := make([][]Vec, len(subpaths))
+		for i, s := range subpaths {
+			polygons[i] = s.points
+		}
+		mask.addFill(polygons, p.rule, p.style.AntiAlias)
+	}
+
+	mask.draw(newBrush(buf, p.style))
+}
+
+// SetTransform sets a transform which is applied about the start of the path. Outlines
+// are scaled by the transform's average scale factor.
+func (p *Path) SetTransform(t Transform) *Path {
+	p.setTransform(t)
+	return p
+}
+
+// viewed returns a copy of the path with a view transform applied after its own,
+// and its opacity scaled.
+func (p *Path) viewed(view Transform, opacity float64) Drawable {
+	v := *p
+	v.transformable = p.withView(view, p.GetPos())
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// applyTransform returns a copy of the path with its transform applied to its points.
+func (p *Path) applyTransform() *Path {
+	m := p.matrix(p.GetPos())
+	return p.transform(m).SetStyle(transformedStyle(p.style, m))
+}
+
+// subpath is a subpath with its curves flattened into straight lines.
+type subpath struct {
+	points []Vec
+	closed bool
+}
+
+// flatten approximates each subpath of the path with straight lines.
+func (p *Path) flatten() []subpath {
+	var subpaths []subpath
+	var current subpath
+	finish := func() {
+		if current.closed && len(current.points) > 1 && current.points[0] == current.points[len(current.points)-1] {
+			current.points = current.points[:len(current.points)-1]
+		}
+		if len(current.points) > 0 {
+			subpaths = append(subpaths, current)
+		}
+		current = subpath{}
+	}
+	addPoint := func(pt Vec) {
+		if n := len(current.points); n == 0 || current.points[n-1] != pt {
+			current.points = append(current.points, pt)
+		}
+	}
+
+	for _, op := range p.ops {
+		var from Vec
+		if n := len(current.points); n > 0 {
+			from = current.points[n-1]
+		}
+
+		switch op.verb {
+		case moveVerb:
+			finish()
+			addPoint(op.points[0])
+		case lineVerb:
+			addPoint(op.points[0])
+		case quadVerb:
+			ctrl, to := op.points[0], op.points[1]
+			n := curveSteps(0.25 * secondDiff(from, ctrl, to))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				addPoint(Vec{
+					u*u*from.X + 2*u*t*ctrl.X + t*t*to.X,
+					u*u*from.Y + 2*u*t*ctrl.Y + t*t*to.Y,
+				})
+			}
+		case cubicVerb:
+			c1, c2, to := op.points[0], op.points[1], op.points[2]
+			n := curveSteps(0.75 * math.Max(secondDiff(from, c1, c2), secondDiff(c1, c2, to)))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				addPoint(Vec{
+					u*u*u*from.X + 3*u*u*t*c1.X + 3*u*t*t*c2.X + t*t*t*to.X,
+					u*u*u*from.Y + 3*u*u*t*c1.Y + 3*u*t*t*c2.Y + t*t*t*to.Y,
+				})
+			}
+		case closeVerb:
+			current.closed = true
+			finish()
+		}
+	}
+	finish()
+	return subpaths
+}
+
+// flatPoints returns every point of the flattened path.
+func (p *Path) flatPoints() []Vec {
+	var points []Vec
+	for _, s := range p.flatten() {
+		points = append(points, s.points...)
+	}
+	return points
+}
+
+// secondDiff returns the size of the second difference of three control points, which
+// measures how sharply a curve through them bends.
+func secondDiff(a, b, c Vec) float64 {
+	return math.Hypot(a.X-2*b.X+c.X, a.Y-2*b.Y+c.Y)
+}
+
+// curveSteps returns the number of straight lines needed to draw a curve within the
+// flattening tolerance, given its scaled second difference (Wang's formula).
+func curveSteps(bend float64) int {
+	return max(1, int(math.Ceil(math.Sqrt(bend/flattenTolerance))))
+}
+
+// pathEdge is a non-horizontal edge of a filled path.
+type pathEdge struct {
+	top, bottom Vec
+	winding     int // 1 if the edge runs downwards, -1 if it runs upwards
+}
+
+// crossing is where a scanline crosses an edge of a filled path.
+type crossing struct {
+	x       float64
+	winding int
+}
+
+// addFill adds the area inside a set of closed polygons to the mask, using a scanline
+// rasteriser. Anti-aliased fills are sampled with several scanlines for each row of
+// pixels, and the exact horizontal coverage of each span.
+func (m *coverageMask) addFill(polygons [][]Vec, rule FillRule, antiAlias bool) {
+	var edges []pathEdge
+	for _, polygon := range polygons {
+		for i := range polygon {
+			a, b := polygon[i], polygon[(i+1)%len(polygon)]
+			switch {
+			case a.Y < b.Y:
+				edges = append(edges, pathEdge{a, b, 1})
+			case a.Y > b.Y:
+				edges = append(edges, pathEdge{b, a, -1})
+			}
+		}
+	}
+	slices.SortFunc(edges, func(a, b pathEdge) int {
+		return cmp.Compare(a.top.Y, b.top.Y)
+	})
+
+	samples := 1
+	if antiAlias {
+		samples = fillSamples
+	}
+	weight := 1 / float32(samples)
+	width := m.rect.Dx()
+	partial := make([]float32, width+1) // coverage of pixels at the ends of spans
+	delta := make([]float32, width+1)   // change in coverage of whole pixels from the left
+
+	var active []pathEdge
+	var crossings []crossing
+	next := 0
+	for y := m.rect.Min.Y; y < m.rect.Max.Y; y++ {
+		clear(partial)
+		clear(delta)
+
+		for s := range samples {
+			sy := float64(y) - 0.5 + (float64(s)+0.5)/float64(samples)
+
+			// Track the edges which the scanline crosses
+			for next < len(edges) && edges[next].top.Y <= sy {
+				active = append(active, edges[next])
+				next++
+			}
+			active = slices.DeleteFunc(active, func(e pathEdge) bool {
+				return e.bottom.Y <= sy
+			})
+
+			crossings = crossings[:0]
+			for _, e := range active {
+				t := (sy - e.top.Y) / (e.bottom.Y - e.top.Y)
+				crossings = append(crossings, crossing{e.top.X + t*(e.bottom.X-e.top.X), e.winding})
+			}
+			slices.SortFunc(crossings, func(a, b crossing) int {
+				return cmp.Compare(a.x, b.x)
+			})
+
+			// Fill the spans between crossings which are inside the path
+			winding := 0
+			for i := 0; i+1 < len(crossings); i++ {
+				winding += crossings[i].winding
+				if !rule.isInside(winding) {
+					continue
+				}
+				x0 := crossings[i].x - float64(m.rect.Min.X)
+				x1 := crossings[i+1].x - float64(m.rect.Min.X)
+				if antiAlias {
+					addSpanCoverage(partial, delta, x0+0.5, x1+0.5, weight)
+				} else {
+					// Fill the pixels whose centres lie within the span
+					i0 := Clamp(int(math.Ceil(x0)), 0, width)
+					i1 := Clamp(int(math.Ceil(x1)), 0, width)
+					if i1 > i0 {
+						delta[i0] += weight
+						delta[i1] -= weight
+					}
+				}
+			}
+		}
+
+		var whole float32
+		for i := range width {
+			whole += delta[i]
+			if coverage := whole + partial[i]; coverage > 0 {
+				m.set(m.rect.Min.X+i, y, float64(coverage))
+			}
+		}
+	}
+}
+
+// addSpanCoverage adds the coverage of a horizontal span from u0 to u1 along a row of
+// pixels, where pixel i spans from i to i+1. Pixels partly covered by the span are
+// added to partial, and pixels wholly covered are added to delta as a running total.
+func addSpanCoverage(partial, delta []float32, u0, u1 float64, weight float32) {
+	width := float64(len(partial) - 1)
+	u0, u1 = Clamp(u0, 0, width), Clamp(u1, 0, width)
+	if u1 <= u0 {
+		return
+	}
+
+	i0, i1 := int(u0), int(u1)
+	if i0 == i1 {
+		partial[i0] += float32(u1-u0) * weight
+		return
+	}
+	partial[i0] += float32(float64(i0+1)-u0) * weight
+	delta[i0+1] += weight
+	delta[i1] -= weight
+	partial[i1] += float32(u1-float64(i1)) * weight
+}
@@ -0,0 +1,97 @@
+package gogl
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathFillRect(t *testing.T) {
+	f := NewFrameBuffer(20, 20)
+	NewPath().
+		MoveTo(Vec{4.5, 4.5}).
+		LineTo(Vec{14.5, 4.5}).
+		LineTo(Vec{14.5, 9.5}).
+		LineTo(Vec{4.5, 9.5}).
+		Close().
+		Draw(f)
+
+	// Pixels are filled if their centres lie inside the path
+	for y := range 20 {
+		for x := range 20 {
+			inside := x >= 5 && x < 15 && y >= 5 && y < 10
+			if filled := f.GetPixel(x, y).A() == 255; filled != inside {
+				t.Errorf("Pixel (%d, %d): expected filled %v, got %v", x, y, inside, filled)
+			}
+		}
+	}
+}
+
+func TestPathFillRules(t *testing.T) {
+	// Two squares wound the same way, one inside the other
+	square := func(rule FillRule) *FrameBuffer {
+		f := NewFrameBuffer(20, 20)
+		NewPath().
+			MoveTo(Vec{0, 0}).LineTo(Vec{20, 0}).LineTo(Vec{20, 20}).LineTo(Vec{0, 20}).Close().
+			MoveTo(Vec{5, 5}).LineTo(Vec{15, 5}).LineTo(Vec{15, 15}).LineTo(Vec{5, 15}).Close().
+			SetFillRule(rule).
+			Draw(f)
+		return f
+	}
+
+	if f := square(FillNonZero); f.GetPixel(10, 10).A() != 255 {
+		t.Errorf("Expected non-zero rule to fill the inner square")
+	}
+	if f := square(FillEvenOdd); f.GetPixel(10, 10).A() != 0 {
+		t.Errorf("Expected even-odd rule to leave the inner square empty")
+	}
+	if f := square(FillEvenOdd); f.GetPixel(2, 2).A() != 255 {
+		t.Errorf("Expected even-odd rule to fill the outer square")
+	}
+}
+
+func TestPathAntiAliasedCoverage(t *testing.T) {
+	// A square covering half of each pixel along its right edge
+	f := NewFrameBuffer(20, 20)
+	NewPath().
+		MoveTo(Vec{4.5, 4.5}).
+		LineTo(Vec{10, 4.5}).
+		LineTo(Vec{10, 14.5}).
+		LineTo(Vec{4.5, 14.5}).
+		Close().
+		SetStyle(Style{Colour: White, AntiAlias: true}).
+		Draw(f)
+
+	if a := f.GetPixel(7, 8).A(); a != 255 {
+		t.Errorf("Expected inner pixel to be fully covered, got alpha %d", a)
+	}
+	if a := f.GetPixel(10, 8).A(); a < 120 || a > 135 {
+		t.Errorf("Expected edge pixel to be half covered, got alpha %d", a)
+	}
+	if a := f.GetPixel(11, 8).A(); a != 0 {
+		t.Errorf("Expected outer pixel to be empty, got alpha %d", a)
+	}
+}
+
+func TestPathArcTo(t *testing.T) {
+	for _, tc := range []struct{ largeArc, sweep bool }{
+		{false, true}, {true, false}, {false, false}, {true, true},
+	} {
+		// Radii too small to reach are scaled up, so every arc is a semicircle
+		p := NewPath().MoveTo(Vec{0, 0}).ArcTo(5, 5, 0, tc.largeArc, tc.sweep, Vec{20, 0})
+		points := p.flatPoints()
+		if end := points[len(points)-1]; end != (Vec{20, 0}) {
+			t.Errorf("Expected arc to end at {20, 0}, got %v", end)
+		}
+		for _, pt := range points {
+			if d := Dist(pt, Vec{10, 0}); math.Abs(d-10) > 0.1 {
+				t.Fatalf("Expected arc point %v to be 10 from the centre, got %.2f", pt, d)
+			}
+		}
+	}
+
+	// Sweeping clockwise (with y pointing down) from left to right passes above
+	p := NewPath().MoveTo(Vec{0, 0}).ArcTo(10, 10, 0, false, true, Vec{20, 0})
+	if mid := p.flatPoints()[len(p.flatPoints())/2]; mid.Y > -9 {
+		t.Errorf("Expected clockwise arc to pass above its end points, got midpoint %v", mid)
+	}
+}
@@ -3,6 +3,7 @@ package gogl
 import (
 	"container/ring"
 	"fmt"
+	"image"
 	"math"
 	"slices"
 	"time"
@@ -15,6 +16,7 @@ type Polygon struct {
 	vertices []Vec
 	style    Style
 	segments []*Triangle
+	transformable
 }
 
 // NewPolygon constructs a polygon from the specified vertices.
@@ -36,6 +38,7 @@ func (p *Polygon) Move(mov Vec) {
 	}
 	// Refresh the triangle segments
 	p.segments = triangulatePoly2Tri(p.vertices)
+	p.SetStyle(p.style)
 }
 
 // Style returns a copy of the polygon's style.
@@ -46,19 +49,96 @@ func (p *Polygon) Style() Style {
 // SetStyle sets the style of a polygon.
 func (p *Polygon) SetStyle(s Style) *Polygon {
 	p.style = s
+	for _, segment := range p.segments {
+		segment.SetStyle(s)
+	}
 	return p
 }
 
+// Bounds returns the pixel bounding box of the polygon.
+func (p *Polygon) Bounds() image.Rectangle {
+	if len(p.vertices) == 0 {
+		return image.Rectangle{}
+	}
+	if p.transformed {
+		return p.transformedPath().Bounds()
+	}
+	minV, maxV := p.vertices[0], p.vertices[0]
+	for _, v := range p.vertices[1:] {
+		minV = Vec{math.Min(minV.X, v.X), math.Min(minV.Y, v.Y)}
+		maxV = Vec{math.Max(maxV.X, v.X), math.Max(maxV.Y, v.Y)}
+	}
+	return pixelBounds(minV.X, minV.Y, maxV.X, maxV.Y)
+}
+
+// SVG returns the polygon as an SVG element.
+func (p *Polygon) SVG() string {
+	return fmt.Sprintf(`<polygon points="%s"%s%s/>`, svgPoints(p.vertices), svgPaint(p.style, 0), p.svgTransform(p.pos()))
+}
+
+// SetTransform sets a transform which is applied about the first vertex of the polygon.
+func (p *Polygon) SetTransform(t Transform) *Polygon {
+	p.setTransform(t)
+	return p
+}
+
+// viewed returns a copy of the polygon with a view transform applied after its own,
+// and its opacity scaled.
+func (p *Polygon) viewed(view Transform, opacity float64) Drawable {
+	v := *p
+	v.transformable = p.withView(view, p.pos())
+	v.style = v.style.faded(opacity)
+	v.segments = make([]*Triangle, len(p.segments))
+	for i, segment := range p.segments {
+		s := *segment
+		s.style = v.style
+		v.segments[i] = &s
+	}
+	return &v
+}
+
+// pos returns the first vertex of the polygon, which it is transformed about.
+func (p *Polygon) pos() Vec {
+	if len(p.vertices) == 0 {
+		return Vec{}
+	}
+	return p.vertices[0]
+}
+
+// transformedPath returns the polygon as a path, with its transform applied.
+func (p *Polygon) transformedPath() *Path {
+	return outlinePath(p.style.solid(), p.matrix(p.pos()), func(path *Path, _ float64) {
+		path.addPolygon(p.vertices)
+	})
+}
+
 // Draw draws the polygon onto the provided frame buffer.
 func (p *Polygon) Draw(buf *FrameBuffer) {
+	if buf.isClipped(p.Bounds()) {
+		return
+	}
+	if p.transformed {
+		p.transformedPath().Draw(buf)
+		return
+	}
+
+	// Anti-aliasing each triangle separately would leave seams along their shared edges
+	if p.style.AntiAlias {
+		drawPolygonAntiAliased(buf, p.vertices, p.Bounds(), p.style)
+		return
+	}
+
+	// Neighbouring triangles share the pixels along their edges, so they are gathered in
+	// a mask and each pixel is only drawn once
+	mask := newCoverageMask(p.Bounds().Intersect(buf.clip))
 	for _, segment := range p.segments {
 		if segment == nil {
 			fmt.Println("Segment nil error", time.Now())
 		} else {
-			segment.SetStyle(p.style)
-			segment.Draw(buf)
+			segment.rasterise(mask.rect, func(x, y int) { mask.set(x, y, 1) })
 		}
 	}
+	mask.draw(newBrush(buf, p.style))
 }
 
 // vertex contains information about a vertex of a polygon.
@@ -222,6 +302,7 @@ func isConvex(a, b, c Vec) bool {
 type Triangle struct {
 	v1, v2, v3 Vec
 	style      Style
+	transformable
 }
 
 // NewTriangle constructs a new triangle from the provided vertices.
@@ -243,8 +324,64 @@ func (t *Triangle) SetStyle(s Style) *Triangle {
 	return t
 }
 
+// Bounds returns the pixel bounding box of the triangle.
+func (t *Triangle) Bounds() image.Rectangle {
+	if t.transformed {
+		return t.transformedPath().Bounds()
+	}
+	return pixelBounds(
+		math.Min(math.Min(t.v1.X, t.v2.X), t.v3.X), math.Min(math.Min(t.v1.Y, t.v2.Y), t.v3.Y),
+		math.Max(math.Max(t.v1.X, t.v2.X), t.v3.X), math.Max(math.Max(t.v1.Y, t.v2.Y), t.v3.Y),
+	)
+}
+
+// SVG returns the triangle as an SVG element.
+func (t *Triangle) SVG() string {
+	return fmt.Sprintf(`<polygon points="%s"%s%s/>`,
+		svgPoints([]Vec{t.v1, t.v2, t.v3}), svgPaint(t.style, 0), t.svgTransform(t.v1))
+}
+
+// SetTransform sets a transform which is applied about the first vertex of the triangle.
+func (t *Triangle) SetTransform(m Transform) *Triangle {
+	t.setTransform(m)
+	return t
+}
+
+// viewed returns a copy of the triangle with a view transform applied after its own,
+// and its opacity scaled.
+func (t *Triangle) viewed(view Transform, opacity float64) Drawable {
+	v := *t
+	v.transformable = t.withView(view, t.v1)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// transformedPath returns the triangle as a path, with its transform applied.
+func (t *Triangle) transformedPath() *Path {
+	return outlinePath(t.style.solid(), t.matrix(t.v1), func(p *Path, _ float64) {
+		p.addPolygon([]Vec{t.v1, t.v2, t.v3})
+	})
+}
+
 // Draw rasterises and draws the triangle onto the provided frame buffer.
 func (t *Triangle) Draw(buf *FrameBuffer) {
+	if buf.isClipped(t.Bounds()) {
+		return
+	}
+	if t.transformed {
+		t.transformedPath().Draw(buf)
+		return
+	}
+
+	if t.style.AntiAlias {
+		drawPolygonAntiAliased(buf, []Vec{t.v1, t.v2, t.v3}, t.Bounds(), t.style)
+		return
+	}
+	t.rasterise(buf.clip, newBrush(buf, t.style).plot)
+}
+
+// rasterise calls plot for each pixel within the area which the triangle covers.
+func (t *Triangle) rasterise(area image.Rectangle, plot func(x, y int)) {
 	// Construct bounding box
 	maxX := math.Max(math.Max(t.v1.X, t.v2.X), t.v3.X)
 	minX := math.Min(math.Min(t.v1.X, t.v2.X), t.v3.X)
@@ -255,10 +392,14 @@ func (t *Triangle) Draw(buf *FrameBuffer) {
 
 	isClockwise := edgeFunction(t.v1, t.v2, t.v3) > 0
 
-	// Iterate over pixels in bounding box
-	for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-		for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
-			p := Vec{float64(x), float64(y)}
+	// Iterate over pixels in bounding box that can be drawn
+	x0, x1 := clipSteps(bbox.Pos.X, bbox.Pos.X+bbox.w, area.Min.X, area.Max.X, roundPixel)
+	y0, y1 := clipSteps(bbox.Pos.Y, bbox.Pos.Y+bbox.h, area.Min.Y, area.Max.Y, roundPixel)
+	for i := x0; i < x1; i++ {
+		x := bbox.Pos.X + float64(i)
+		for j := y0; j < y1; j++ {
+			y := bbox.Pos.Y + float64(j)
+			p := Vec{x, y}
 			ABP := edgeFunction(t.v1, t.v2, p)
 			BCP := edgeFunction(t.v2, t.v3, p)
 			CAP := edgeFunction(t.v3, t.v1, p)
@@ -268,12 +409,55 @@ func (t *Triangle) Draw(buf *FrameBuffer) {
 			if (isClockwise && ABP >= 0 && BCP >= 0 && CAP >= 0) ||
 				(!isClockwise && ABP <= 0 && BCP <= 0 && CAP <= 0) {
 				jInt, iInt := int(math.Round(y)), int(math.Round(x))
-				buf.SetPixel(iInt, jInt, NewPixel(t.style.Colour))
+				plot(iInt, jInt)
+			}
+		}
+	}
+}
+
+// drawPolygonAntiAliased draws a filled polygon with smooth edges, by scaling the alpha
+// of each pixel by how much of it the polygon covers. Coverage is estimated from the
+// distance to the nearest edge.
+func drawPolygonAntiAliased(buf *FrameBuffer, vertices []Vec, bounds image.Rectangle, style Style) {
+	area := bounds.Intersect(buf.clip)
+	b := newBrush(buf, style)
+	for x := area.Min.X; x < area.Max.X; x++ {
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			p := Vec{float64(x), float64(y)}
+
+			// Find the distance to the nearest edge, and whether the point is inside
+			// using the even-odd rule
+			dist := math.Inf(1)
+			inside := false
+			for i := range vertices {
+				a, c := vertices[i], vertices[(i+1)%len(vertices)]
+				dist = math.Min(dist, distToSegment(p, a, c))
+				if (a.Y > p.Y) != (c.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(c.X-a.X)/(c.Y-a.Y) {
+					inside = !inside
+				}
+			}
+			if !inside {
+				dist = -dist
+			}
+
+			if coverage := edgeCoverage(dist); coverage > 0 {
+				b.plotCoverage(x, y, coverage)
 			}
 		}
 	}
 }
 
+// distToSegment returns the distance from point p to the line segment from a to b.
+func distToSegment(p, a, b Vec) float64 {
+	ab := Sub(b, a)
+	lenSq := ab.X*ab.X + ab.Y*ab.Y
+	if lenSq == 0 {
+		return Dist(p, a)
+	}
+	t := Clamp(((p.X-a.X)*ab.X+(p.Y-a.Y)*ab.Y)/lenSq, 0, 1)
+	return Dist(p, Vec{a.X + ab.X*t, a.Y + ab.Y*t})
+}
+
 // pointInTriangle returns true if point p exists within the area of the triangle.
 // This function uses the barycentric coordinate method.
 func (t *Triangle) pointInTriangle(p Vec) bool {
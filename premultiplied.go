@@ -0,0 +1,297 @@
+package gogl
+
+import (
+	"image/color"
+	"math"
+)
+
+// NewPremultipliedFrameBuffer constructs a new frame buffer with a particular width and
+// height, which stores pixels with premultiplied alpha. Blending premultiplied pixels
+// avoids a division per channel, so drawing is faster, at the cost of some colour
+// precision in translucent pixels.
+//
+// Pixels passed to and returned from the frame buffer's methods are still straight
+// alpha; they are converted as they go in and out. Only Bytes and image export pay the
+// cost of converting the whole frame buffer back to straight alpha.
+func NewPremultipliedFrameBuffer(width, height int) *FrameBuffer {
+	f := NewFrameBuffer(width, height)
+	f.premultiplied = true
+	return f
+}
+
+// IsPremultiplied returns true if the frame buffer stores pixels with premultiplied
+// alpha.
+func (f *FrameBuffer) IsPremultiplied() bool {
+	return f.premultiplied
+}
+
+// NewPremultipliedPixel constructs a pixel with premultiplied alpha from a colour.
+func NewPremultipliedPixel(c color.Color) Pixel {
+	return NewPixel(c).Premultiply()
+}
+
+// Premultiply converts a straight alpha pixel to premultiplied alpha.
+func (p Pixel) Premultiply() Pixel {
+	a := p.A()
+	if a == math.MaxUint8 {
+		return p
+	}
+	return scalePixel(p, uint32(a))&^0xff | Pixel(a)
+}
+
+// Unpremultiply converts a premultiplied alpha pixel to straight alpha.
+func (p Pixel) Unpremultiply() Pixel {
+	a := uint32(p.A())
+	switch a {
+	case math.MaxUint8:
+		return p
+	case 0:
+		return 0
+	}
+	b := min((uint32(p.B())*math.MaxUint8+a/2)/a, math.MaxUint8)
+	g := min((uint32(p.G())*math.MaxUint8+a/2)/a, math.MaxUint8)
+	r := min((uint32(p.R())*math.MaxUint8+a/2)/a, math.MaxUint8)
+	return pack(uint8(a), uint8(b), uint8(g), uint8(r))
+}
+
+// toNative converts a straight alpha pixel to the frame buffer's alpha format.
+func (f *FrameBuffer) toNative(p Pixel) Pixel {
+	if f.premultiplied {
+		return p.Premultiply()
+	}
+	return p
+}
+
+// toStraight converts a pixel in the frame buffer's alpha format to straight alpha.
+func (f *FrameBuffer) toStraight(p Pixel) Pixel {
+	if f.premultiplied {
+		return p.Unpremultiply()
+	}
+	return p
+}
+
+// nativeBlend returns the version of a straight alpha blend function which works with
+// the frame buffer's alpha format.
+func (f *FrameBuffer) nativeBlend(blend BlendFunc) BlendFunc {
+	if f.premultiplied {
+		return PremultipliedBlend(blend)
+	}
+	return blend
+}
+
+// convertFrom returns a function which converts pixels from the source frame buffer's
+// alpha format to this frame buffer's, or nil if the formats match.
+func (f *FrameBuffer) convertFrom(src *FrameBuffer) func(Pixel) Pixel {
+	switch {
+	case f.premultiplied == src.premultiplied:
+		return nil
+	case f.premultiplied:
+		return Pixel.Premultiply
+	default:
+		return Pixel.Unpremultiply
+	}
+}
+
+// AlphaBlendPremultiplied is the premultiplied alpha version of AlphaBlend. Both pixels
+// must have premultiplied alpha.
+func AlphaBlendPremultiplied(src, dst Pixel) Pixel {
+	switch src.A() {
+	case math.MaxUint8:
+		return src
+	case 0:
+		return dst
+	}
+
+	// A premultiplied channel never exceeds its alpha, so the sum can't overflow
+	return src + scalePixel(dst, math.MaxUint8-uint32(src.A()))
+}
+
+// AdditiveBlendPremultiplied is the premultiplied alpha version of AdditiveBlend. Unlike
+// AdditiveBlend, the alpha values are also added, and the source colour is weighted by
+// its alpha.
+func AdditiveBlendPremultiplied(src, dst Pixel) Pixel {
+	return addPixels(src, dst)
+}
+
+// DstOverBlendPremultiplied is the premultiplied alpha version of DstOverBlend.
+func DstOverBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, math.MaxUint8-uint32(dst.A()), math.MaxUint8)
+}
+
+// SrcInBlendPremultiplied is the premultiplied alpha version of SrcInBlend.
+func SrcInBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, uint32(dst.A()), 0)
+}
+
+// DstInBlendPremultiplied is the premultiplied alpha version of DstInBlend.
+func DstInBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, 0, uint32(src.A()))
+}
+
+// SrcOutBlendPremultiplied is the premultiplied alpha version of SrcOutBlend.
+func SrcOutBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, math.MaxUint8-uint32(dst.A()), 0)
+}
+
+// DstOutBlendPremultiplied is the premultiplied alpha version of DstOutBlend.
+func DstOutBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, 0, math.MaxUint8-uint32(src.A()))
+}
+
+// SrcAtopBlendPremultiplied is the premultiplied alpha version of SrcAtopBlend.
+func SrcAtopBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, uint32(dst.A()), math.MaxUint8-uint32(src.A()))
+}
+
+// DstAtopBlendPremultiplied is the premultiplied alpha version of DstAtopBlend.
+func DstAtopBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, math.MaxUint8-uint32(dst.A()), uint32(src.A()))
+}
+
+// XorBlendPremultiplied is the premultiplied alpha version of XorBlend.
+func XorBlendPremultiplied(src, dst Pixel) Pixel {
+	return porterDuffPremultiplied(src, dst, math.MaxUint8-uint32(dst.A()), math.MaxUint8-uint32(src.A()))
+}
+
+// MultiplyBlendPremultiplied is the premultiplied alpha version of MultiplyBlend.
+func MultiplyBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(MultiplyBlend, src, dst)
+}
+
+// ScreenBlendPremultiplied is the premultiplied alpha version of ScreenBlend.
+func ScreenBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(ScreenBlend, src, dst)
+}
+
+// OverlayBlendPremultiplied is the premultiplied alpha version of OverlayBlend.
+func OverlayBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(OverlayBlend, src, dst)
+}
+
+// DarkenBlendPremultiplied is the premultiplied alpha version of DarkenBlend.
+func DarkenBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(DarkenBlend, src, dst)
+}
+
+// LightenBlendPremultiplied is the premultiplied alpha version of LightenBlend.
+func LightenBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(LightenBlend, src, dst)
+}
+
+// ColourDodgeBlendPremultiplied is the premultiplied alpha version of ColourDodgeBlend.
+func ColourDodgeBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(ColourDodgeBlend, src, dst)
+}
+
+// ColourBurnBlendPremultiplied is the premultiplied alpha version of ColourBurnBlend.
+func ColourBurnBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(ColourBurnBlend, src, dst)
+}
+
+// HardLightBlendPremultiplied is the premultiplied alpha version of HardLightBlend.
+func HardLightBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(HardLightBlend, src, dst)
+}
+
+// SoftLightBlendPremultiplied is the premultiplied alpha version of SoftLightBlend.
+func SoftLightBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(SoftLightBlend, src, dst)
+}
+
+// DifferenceBlendPremultiplied is the premultiplied alpha version of DifferenceBlend.
+func DifferenceBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(DifferenceBlend, src, dst)
+}
+
+// ExclusionBlendPremultiplied is the premultiplied alpha version of ExclusionBlend.
+func ExclusionBlendPremultiplied(src, dst Pixel) Pixel {
+	return viaStraight(ExclusionBlend, src, dst)
+}
+
+// premultipliedBlends maps each straight alpha blend function to its premultiplied
+// version. ClearBlend, SrcBlend and DstBlend work with either format.
+var premultipliedBlends = map[uintptr]BlendFunc{}
+
+// alphaBlendPointer is the address of AlphaBlend's code.
+var alphaBlendPointer = blendFuncPointer(AlphaBlend)
+
+func init() {
+	for _, pair := range [][2]BlendFunc{
+		{ClearBlend, ClearBlend},
+		{SrcBlend, SrcBlend},
+		{DstBlend, DstBlend},
+		{AlphaBlend, AlphaBlendPremultiplied},
+		{AdditiveBlend, AdditiveBlendPremultiplied},
+		{DstOverBlend, DstOverBlendPremultiplied},
+		{SrcInBlend, SrcInBlendPremultiplied},
+		{DstInBlend, DstInBlendPremultiplied},
+		{SrcOutBlend, SrcOutBlendPremultiplied},
+		{DstOutBlend, DstOutBlendPremultiplied},
+		{SrcAtopBlend, SrcAtopBlendPremultiplied},
+		{DstAtopBlend, DstAtopBlendPremultiplied},
+		{XorBlend, XorBlendPremultiplied},
+		{MultiplyBlend, MultiplyBlendPremultiplied},
+		{ScreenBlend, ScreenBlendPremultiplied},
+		{OverlayBlend, OverlayBlendPremultiplied},
+		{DarkenBlend, DarkenBlendPremultiplied},
+		{LightenBlend, LightenBlendPremultiplied},
+		{ColourDodgeBlend, ColourDodgeBlendPremultiplied},
+		{ColourBurnBlend, ColourBurnBlendPremultiplied},
+		{HardLightBlend, HardLightBlendPremultiplied},
+		{SoftLightBlend, SoftLightBlendPremultiplied},
+		{DifferenceBlend, DifferenceBlendPremultiplied},
+		{ExclusionBlend, ExclusionBlendPremultiplied},
+	} {
+		// Premultiplied versions map to themselves, so they can be passed either way
+		premultipliedBlends[blendFuncPointer(pair[0])] = pair[1]
+		premultipliedBlends[blendFuncPointer(pair[1])] = pair[1]
+	}
+}
+
+// PremultipliedBlend returns the premultiplied alpha version of a blend function. Blend
+// functions not provided by this package are wrapped, so that they receive and return
+// straight alpha pixels.
+func PremultipliedBlend(blend BlendFunc) BlendFunc {
+	// Alpha blending is by far the most common, so it skips the map lookup. This keeps
+	// SetPixelFunc about as fast on premultiplied frame buffers as on straight ones.
+	ptr := blendFuncPointer(blend)
+	if ptr == alphaBlendPointer {
+		return AlphaBlendPremultiplied
+	}
+	if p, ok := premultipliedBlends[ptr]; ok {
+		return p
+	}
+	return func(src, dst Pixel) Pixel {
+		return viaStraight(blend, src, dst)
+	}
+}
+
+// viaStraight blends premultiplied pixels using a straight alpha blend function.
+func viaStraight(blend BlendFunc, src, dst Pixel) Pixel {
+	return blend(src.Unpremultiply(), dst.Unpremultiply()).Premultiply()
+}
+
+// porterDuffPremultiplied composites a premultiplied source and destination pixel,
+// where fa and fb are the fractions of the source and destination to keep, scaled from
+// 0 to 255.
+func porterDuffPremultiplied(src, dst Pixel, fa, fb uint32) Pixel {
+	return addPixels(scalePixel(src, fa), scalePixel(dst, fb))
+}
+
+// scalePixel multiplies every channel of a pixel by s/255, rounding to the nearest
+// value. Pairs of channels are scaled together in 16-bit lanes.
+func scalePixel(p Pixel, s uint32) Pixel {
+	ag := uint32(p)&0x00ff00ff*s + 0x00800080
+	br := uint32(p)>>8&0x00ff00ff*s + 0x00800080
+	ag = (ag + ag>>8&0x00ff00ff) >> 8 & 0x00ff00ff
+	br = (br + br>>8&0x00ff00ff) & 0xff00ff00
+	return Pixel(ag | br)
+}
+
+// addPixels adds each channel of two pixels, saturating at 255.
+func addPixels(p, q Pixel) Pixel {
+	channel := func(shift uint) uint32 {
+		return min(uint32(p>>shift&0xff)+uint32(q>>shift&0xff), math.MaxUint8) << shift
+	}
+	return Pixel(channel(0) | channel(8) | channel(16) | channel(24))
+}
@@ -0,0 +1,111 @@
+package gogl
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPremultiply(t *testing.T) {
+	for _, tc := range []struct {
+		straight, premultiplied Pixel
+	}{
+		{pack(255, 10, 20, 30), pack(255, 10, 20, 30)},
+		{pack(128, 255, 100, 0), pack(128, 128, 50, 0)},
+		{pack(51, 255, 255, 255), pack(51, 51, 51, 51)},
+		{pack(0, 255, 255, 255), pack(0, 0, 0, 0)},
+	} {
+		if got := tc.straight.Premultiply(); got != tc.premultiplied {
+			t.Errorf("Premultiply(%08x): expected %08x, got %08x", uint32(tc.straight), uint32(tc.premultiplied), uint32(got))
+		}
+	}
+
+	// Converting back should be lossless when alpha is high enough
+	p := NewPixel(color.RGBA{200, 40, 60, 255})
+	if got := p.Premultiply().Unpremultiply(); got != p {
+		t.Errorf("Expected %08x after round trip, got %08x", uint32(p), uint32(got))
+	}
+}
+
+func TestPremultipliedBlendModes(t *testing.T) {
+	// Alpha values of 0.6 and 0.8 premultiply these colours exactly, so that any error
+	// comes from the blend functions alone
+	src := NewPixel(color.RGBA{200, 40, 60, 153})
+	dst := NewPixel(color.RGBA{20, 120, 220, 204})
+
+	modes := append([]struct {
+		name  string
+		blend BlendFunc
+	}{{"Alpha", AlphaBlend}}, blendModes...)
+
+	// Compare in premultiplied space, where rounding errors aren't magnified by
+	// dividing by a small alpha
+	const tolerance = 2
+	for _, mode := range modes {
+		want := mode.blend(src, dst).Premultiply()
+		got := PremultipliedBlend(mode.blend)(src.Premultiply(), dst.Premultiply())
+		for _, c := range [][2]uint8{
+			{want.R(), got.R()}, {want.G(), got.G()}, {want.B(), got.B()}, {want.A(), got.A()},
+		} {
+			if max(c[0], c[1])-min(c[0], c[1]) > tolerance {
+				t.Errorf("%s: expected %08x, got %08x", mode.name, uint32(want), uint32(got))
+				break
+			}
+		}
+	}
+}
+
+func TestPremultipliedFrameBuffer(t *testing.T) {
+	straight := NewFrameBuffer(32, 32)
+	premultiplied := NewPremultipliedFrameBuffer(32, 32)
+
+	for _, buf := range []*FrameBuffer{straight, premultiplied} {
+		buf.Fill(color.RGBA{0, 0, 40, 200})
+		NewRect(20, 20, Vec{2, 2}).SetStyle(Style{Colour: color.RGBA{255, 0, 0, 255}, Opacity: new(0.6)}).Draw(buf)
+		NewCircle(20, Vec{18, 18}).SetStyle(Style{Colour: color.RGBA{0, 255, 100, 120}}).Draw(buf)
+		buf.SetPixel(0, 0, NewPixel(color.RGBA{255, 255, 255, 100}))
+	}
+
+	const tolerance = 2
+	want, got := straight.Bytes(), premultiplied.Bytes()
+	for i := range want {
+		if max(want[i], got[i])-min(want[i], got[i]) > tolerance {
+			x, y := i/pxLen%32, i/pxLen/32
+			t.Fatalf("Pixel (%d, %d): expected %08x, got %08x", x, y, uint32(straight.GetPixel(x, y)), uint32(premultiplied.GetPixel(x, y)))
+		}
+	}
+
+	// Blitting between formats converts the pixels
+	dst := NewFrameBuffer(32, 32)
+	dst.Blit(premultiplied, premultiplied.Bounds(), image.Point{}, SrcBlend)
+	if got, want := dst.GetPixel(10, 10), premultiplied.GetPixel(10, 10); got != want {
+		t.Errorf("Expected blitted pixel %08x, got %08x", uint32(want), uint32(got))
+	}
+}
+
+func BenchmarkDrawTranslucent(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		buf  *FrameBuffer
+	}{
+		{"Straight", NewFrameBuffer(256, 256)},
+		{"Premultiplied", NewPremultipliedFrameBuffer(256, 256)},
+	} {
+		circle := NewCircle(200, Vec{128, 128}).SetStyle(Style{Colour: color.RGBA{255, 0, 0, 128}})
+		b.Run(tc.name, func(b *testing.B) {
+			tc.buf.Fill(color.RGBA{0, 0, 255, 255})
+			for n := 0; n < b.N; n++ {
+				circle.Draw(tc.buf)
+			}
+		})
+	}
+}
+
+func BenchmarkBytesPremultiplied(b *testing.B) {
+	buf := NewPremultipliedFrameBuffer(256, 256)
+	buf.Fill(color.RGBA{255, 0, 0, 128})
+
+	for n := 0; n < b.N; n++ {
+		_ = buf.Bytes()
+	}
+}
@@ -1,7 +1,8 @@
 package gogl
 
 import (
-	"image/color"
+	"fmt"
+	"image"
 	"math"
 )
 
@@ -11,6 +12,7 @@ type Rect struct {
 	Direction Vec
 	w, h      float64
 	style     Style
+	transformable
 }
 
 var _ Shape = (*Rect)(nil)
@@ -29,33 +31,48 @@ func NewRect(width, height float64, pos Vec) *Rect {
 
 // Draw draws the rectangle onto the provided frame buffer.
 func (e *Rect) Draw(buf *FrameBuffer) {
+	if e.transformed {
+		e.transformedPath().Draw(buf)
+		return
+	}
+	if buf.isClipped(e.Bounds()) {
+		return
+	}
+
+	b := newBrush(buf, e.style)
 	if e.style.Thickness == 0 {
-		for x := 0; x <= int(math.Round(e.w)); x++ {
-			for y := 0; y <= int(math.Round(e.h)); y++ {
-				xInt, yInt := int(math.Round(e.Pos.X)), int(math.Round(e.Pos.Y))
-				buf.SetPixel(xInt+x, yInt+y, NewPixel(e.style.Colour))
-			}
-		}
-		if e.style.Bloom > 0 {
-			e.drawBloom(buf)
-		}
-	} else {
-		// Draw each edge as its own rectangle
-		NewRect(e.w, e.style.Thickness, e.Pos).
-			SetStyle(Style{e.style.Colour, 0, 0}).
-			Draw(buf)
-		NewRect(e.w, e.style.Thickness, Vec{e.Pos.X, e.Pos.Y + float64(e.h) - float64(e.style.Thickness)}).
-			SetStyle(Style{e.style.Colour, 0, 0}).
-			Draw(buf)
-		NewRect(e.style.Thickness, e.h, e.Pos).
-			SetStyle(Style{e.style.Colour, 0, 0}).
-			Draw(buf)
-		NewRect(e.style.Thickness, e.h, Vec{e.Pos.X + float64(e.w) - float64(e.style.Thickness), e.Pos.Y}).
-			SetStyle(Style{e.style.Colour, 0, 0}).
-			Draw(buf)
-
-		if e.style.Bloom > 0 {
-			e.drawBloom(buf)
+		plotArea(b, rectPixels(e.Pos, e.w, e.h).Intersect(buf.clip))
+		return
+	}
+
+	// Draw each edge as its own rectangle. The sides are trimmed so that the edges don't
+	// overlap, otherwise translucent corners would be blended twice.
+	t := e.style.Thickness
+	top := rectPixels(e.Pos, e.w, t)
+	bottom := rectPixels(Vec{e.Pos.X, e.Pos.Y + e.h - t}, e.w, t)
+	bottom.Min.Y = max(bottom.Min.Y, top.Max.Y)
+	left := rectPixels(e.Pos, t, e.h)
+	left.Min.Y, left.Max.Y = top.Max.Y, bottom.Min.Y
+	right := rectPixels(Vec{e.Pos.X + e.w - t, e.Pos.Y}, t, e.h)
+	right.Min.Y, right.Max.Y = top.Max.Y, bottom.Min.Y
+	right.Min.X = max(right.Min.X, left.Max.X)
+	for _, edge := range []image.Rectangle{top, bottom, left, right} {
+		plotArea(b, edge.Intersect(buf.clip))
+	}
+}
+
+// rectPixels returns the pixels covered by an axis-aligned rectangle, which includes the
+// pixels along its bottom and right edges.
+func rectPixels(pos Vec, w, h float64) image.Rectangle {
+	x, y := int(math.Round(pos.X)), int(math.Round(pos.Y))
+	return image.Rect(x, y, x+int(math.Round(w))+1, y+int(math.Round(h))+1)
+}
+
+// plotArea draws every pixel in the area with the brush.
+func plotArea(b brush, area image.Rectangle) {
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			b.plot(x, y)
 		}
 	}
 }
@@ -113,38 +130,58 @@ func (e *Rect) String() string {
 	return "rectangle"
 }
 
+// Bounds returns the pixel bounding box of the rectangle.
+func (e *Rect) Bounds() image.Rectangle {
+	if e.transformed {
+		return e.transformedPath().Bounds()
+	}
+	return pixelBounds(e.Pos.X, e.Pos.Y, e.Pos.X+e.w, e.Pos.Y+e.h)
+}
+
+// SVG returns the rectangle as an SVG element. Outlines are drawn inside the edges of
+// the rectangle, as they are on the frame buffer.
+func (e *Rect) SVG() string {
+	x, y, w, h := e.Pos.X, e.Pos.Y, e.w, e.h
+	thickness := e.style.Thickness
+	if thickness > 0 && 2*thickness < math.Min(w, h) {
+		x, y, w, h = x+thickness/2, y+thickness/2, w-thickness, h-thickness
+	} else {
+		thickness = 0
+	}
+	return fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s"%s%s/>`,
+		svgNum(x), svgNum(y), svgNum(w), svgNum(h), svgPaint(e.style, thickness), e.svgTransform(e.Pos))
+}
+
 // IsWithin returns whether a position lies within the rectangle's perimeter.
 func (e *Rect) IsWithin(pos Vec) bool {
-	return (pos.X >= e.Pos.X) && (pos.X <= e.Pos.X+e.Width()) &&
+	pos, ok := e.untransform(pos, e.Pos)
+	return ok && (pos.X >= e.Pos.X) && (pos.X <= e.Pos.X+e.Width()) &&
 		(pos.Y >= e.Pos.Y) && (pos.Y <= e.Pos.Y+e.Height())
 }
 
-// drawBloom draws a bloom effect around the shape.
-func (e *Rect) drawBloom(buf *FrameBuffer) {
-	// Draw borders around the rectangle of increasing size and decreasing intensity.
-	for dist := 1; dist <= e.style.Bloom; dist++ {
-		x, y := int(math.Round(e.Pos.X)), int(math.Round(e.Pos.Y))
-		topLeftX := x - dist
-		topLeftY := y - dist
-		topRightX := x + int(math.Round(e.w)) + dist
-		bottomLeftY := y + int(math.Round(e.h)) + dist
-
-		// Calculate colour from distance away from shape body
-		brightness := 1 - (float64(dist) / float64(e.style.Bloom))
-		r, g, b, a := RGBA8(e.style.Colour)
-		bloomColour := color.RGBA{r, g, b, uint8(brightness * float64(a))}
-
-		// Draw top and bottom bloom
-		for i := topLeftX + 1; i < topRightX; i++ {
-			buf.SetPixel(i, topLeftY, NewPixel(bloomColour))
-			buf.SetPixel(i, bottomLeftY, NewPixel(bloomColour))
-		}
-		// Draw left and right bloom
-		for i := topLeftY; i <= bottomLeftY; i++ {
-			buf.SetPixel(topLeftX, i, NewPixel(bloomColour))
-			buf.SetPixel(topRightX, i, NewPixel(bloomColour))
+// SetTransform sets a transform which is applied about the top left corner of the
+// rectangle.
+func (e *Rect) SetTransform(t Transform) *Rect {
+	e.setTransform(t)
+	return e
+}
+
+// viewed returns a copy of the rectangle with a view transform applied after its own,
+// and its opacity scaled.
+func (e *Rect) viewed(view Transform, opacity float64) Drawable {
+	v := *e
+	v.transformable = e.withView(view, e.Pos)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// transformedPath returns the rectangle as a path, with its transform applied.
+func (e *Rect) transformedPath() *Path {
+	return outlinePath(e.style, e.matrix(e.Pos), func(p *Path, inset float64) {
+		if 2*inset < math.Min(e.w, e.h) {
+			p.addRoundedRect(Vec{e.Pos.X + inset, e.Pos.Y + inset}, e.w-2*inset, e.h-2*inset, 0)
 		}
-	}
+	})
 }
 
 // CurvedRect is a rectangle with rounded corners, aligned to the top-left.
@@ -154,6 +191,7 @@ type CurvedRect struct {
 	w, h      float64
 	style     Style
 	radius    float64
+	transformable
 }
 
 var _ Shape = (*CurvedRect)(nil)
@@ -174,12 +212,70 @@ func NewCurvedRect(width, height, radius float64, pos Vec) *CurvedRect {
 // IsWithin returns whether a position lies within the curved rectangle's perimeter.
 func (r *CurvedRect) IsWithin(pos Vec) bool {
 	// Note: this doesn't account for the rounded corners
-	return (pos.X >= r.Pos.X) && (pos.X <= r.Pos.X+r.Width()) &&
+	pos, ok := r.untransform(pos, r.Pos)
+	return ok && (pos.X >= r.Pos.X) && (pos.X <= r.Pos.X+r.Width()) &&
 		(pos.Y >= r.Pos.Y) && (pos.Y <= r.Pos.Y+r.Height())
 }
 
+// Bounds returns the pixel bounding box of the curved rectangle.
+func (r *CurvedRect) Bounds() image.Rectangle {
+	if r.transformed {
+		return r.transformedPath().Bounds()
+	}
+	return pixelBounds(r.Pos.X, r.Pos.Y, r.Pos.X+r.w, r.Pos.Y+r.h)
+}
+
+// SVG returns the curved rectangle as an SVG element. Outlines are drawn inside the
+// edges of the rectangle, as they are on the frame buffer.
+func (r *CurvedRect) SVG() string {
+	x, y, w, h, radius := r.Pos.X, r.Pos.Y, r.w, r.h, r.radius
+	thickness := r.style.Thickness
+	if thickness > 0 && 2*thickness < math.Min(w, h) {
+		x, y, w, h = x+thickness/2, y+thickness/2, w-thickness, h-thickness
+		radius = max(radius-thickness/2, 0)
+	} else {
+		thickness = 0
+	}
+	return fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s"%s%s/>`,
+		svgNum(x), svgNum(y), svgNum(w), svgNum(h), svgNum(radius), svgPaint(r.style, thickness),
+		r.svgTransform(r.Pos))
+}
+
+// SetTransform sets a transform which is applied about the top left corner of the
+// curved rectangle.
+func (r *CurvedRect) SetTransform(t Transform) *CurvedRect {
+	r.setTransform(t)
+	return r
+}
+
+// viewed returns a copy of the curved rectangle with a view transform applied after its own,
+// and its opacity scaled.
+func (r *CurvedRect) viewed(view Transform, opacity float64) Drawable {
+	v := *r
+	v.transformable = r.withView(view, r.Pos)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// transformedPath returns the curved rectangle as a path, with its transform applied.
+func (r *CurvedRect) transformedPath() *Path {
+	return outlinePath(r.style, r.matrix(r.Pos), func(p *Path, inset float64) {
+		if 2*inset < math.Min(r.w, r.h) {
+			p.addRoundedRect(Vec{r.Pos.X + inset, r.Pos.Y + inset}, r.w-2*inset, r.h-2*inset, max(r.radius-inset, 0))
+		}
+	})
+}
+
 // Draw draws the curved rectangle onto the provided frame buffer.
 func (r *CurvedRect) Draw(buf *FrameBuffer) {
+	if r.transformed {
+		r.transformedPath().Draw(buf)
+		return
+	}
+	if buf.isClipped(r.Bounds()) {
+		return
+	}
+
 	subRectHeight := r.style.Thickness
 	subRectWidth := r.style.Thickness
 	if r.style.Thickness == 0 {
@@ -188,27 +284,41 @@ func (r *CurvedRect) Draw(buf *FrameBuffer) {
 		subRectHeight = r.h / 2
 	}
 
-	// Draw each edge as its own rectangle
-	NewRect(r.w-2*(r.radius), subRectHeight, Vec{r.Pos.X + r.radius, r.Pos.Y}).
-		SetStyle(Style{r.style.Colour, 0, 0}).
-		Draw(buf)
-	NewRect(r.w-2*(r.radius), subRectHeight, Vec{r.Pos.X + r.radius, r.Pos.Y + r.h - subRectHeight}).
-		SetStyle(Style{r.style.Colour, 0, 0}).
-		Draw(buf)
-	NewRect(subRectWidth, r.h-2*r.radius, Vec{r.Pos.X, r.Pos.Y + r.radius}).
-		SetStyle(Style{r.style.Colour, 0, 0}).
-		Draw(buf)
-	NewRect(subRectWidth, r.h-2*r.radius, Vec{r.Pos.X + r.w - subRectWidth, r.Pos.Y + r.radius}).
-		SetStyle(Style{r.style.Colour, 0, 0}).
-		Draw(buf)
-
-	// Draw rounded corners
+	// The parts of the shape overlap, so they are gathered in a mask and each pixel is
+	// only drawn once
+	mask := newCoverageMask(r.Bounds().Intersect(buf.clip))
+	addRect := func(pos Vec, w, h float64) {
+		mask.forEach(rectPixels(pos, w, h), func(x, y int, _ Vec) { mask.set(x, y, 1) })
+	}
+
+	// Add each edge as its own rectangle
+	addRect(Vec{r.Pos.X + r.radius, r.Pos.Y}, r.w-2*(r.radius), subRectHeight)
+	addRect(Vec{r.Pos.X + r.radius, r.Pos.Y + r.h - subRectHeight}, r.w-2*(r.radius), subRectHeight)
+	addRect(Vec{r.Pos.X, r.Pos.Y + r.radius}, subRectWidth, r.h-2*r.radius)
+	addRect(Vec{r.Pos.X + r.w - subRectWidth, r.Pos.Y + r.radius}, subRectWidth, r.h-2*r.radius)
+
+	// Add rounded corners
 	drawCorner := func(bbox *Rect, origin Vec) {
-		// Iterate over every pixel in the bounding box
-		for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-			for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
+		// Iterate over every pixel in the bounding box that can be drawn
+		x0, x1 := clipSteps(bbox.Pos.X, bbox.Pos.X+bbox.w, buf.clip.Min.X, buf.clip.Max.X, roundPixel)
+		y0, y1 := clipSteps(bbox.Pos.Y, bbox.Pos.Y+bbox.h, buf.clip.Min.Y, buf.clip.Max.Y, roundPixel)
+		for i := x0; i < x1; i++ {
+			x := bbox.Pos.X + float64(i)
+			for j := y0; j < y1; j++ {
+				y := bbox.Pos.Y + float64(j)
 				dist := Dist(origin, Vec{x, y})
 
+				if r.style.AntiAlias {
+					coverage := edgeCoverage(r.radius - dist)
+					if r.style.Thickness > 0 {
+						coverage -= edgeCoverage(r.radius - r.style.Thickness - dist)
+					}
+					if coverage > 0 {
+						mask.set(int(math.Round(x)), int(math.Round(y)), coverage)
+					}
+					continue
+				}
+
 				withinCircle := func() bool {
 					if r.style.Thickness == 0 {
 						return dist <= r.radius
@@ -217,7 +327,7 @@ func (r *CurvedRect) Draw(buf *FrameBuffer) {
 				}()
 
 				if withinCircle {
-					buf.SetPixel(int(math.Round(x)), int(math.Round(y)), NewPixel(r.style.Colour))
+					mask.set(int(math.Round(x)), int(math.Round(y)), 1)
 				}
 			}
 		}
@@ -240,9 +350,7 @@ func (r *CurvedRect) Draw(buf *FrameBuffer) {
 	bbox = NewRect(bboxSize, bboxSize, Vec{r.Pos.X + r.w - r.radius, r.Pos.Y + r.h - r.radius})
 	drawCorner(bbox, Vec{bbox.Pos.X, bbox.Pos.Y})
 
-	if r.style.Bloom > 0 {
-		r.drawBloom(buf)
-	}
+	mask.draw(newBrush(buf, r.style))
 }
 
 // Width returns the pixel width of the curved rectangle.
@@ -297,64 +405,3 @@ func (r *CurvedRect) Move(px Vec) {
 func (r *CurvedRect) String() string {
 	return "curved rectangle"
 }
-
-// drawBloom draws a bloom effect around the shape.
-func (r *CurvedRect) drawBloom(buf *FrameBuffer) {
-	bloom := float64(r.style.Bloom)
-
-	// Draw straight edge bloom
-	for dist := 1; dist <= r.style.Bloom; dist++ {
-		// Calculate colour from distance away from shape body
-		brightness := 1 - (float64(dist) / bloom)
-		R, G, B, A := RGBA8(r.style.Colour)
-		bloomColour := color.RGBA{R, G, B, uint8(brightness * float64(A))}
-
-		// Top and bottom bloom
-		for x := r.Pos.X + r.radius + 1; x < r.Pos.X+r.w-r.radius; x++ {
-			buf.SetPixel(int(x), int(r.Pos.Y)-dist, NewPixel(bloomColour))
-			buf.SetPixel(int(x), int(r.Pos.Y+r.h)+dist, NewPixel(bloomColour))
-		}
-
-		// Left and right
-		for y := r.Pos.Y + r.radius + 1; y < r.Pos.Y+r.h-r.radius; y++ {
-			buf.SetPixel(int(r.Pos.X)-dist, int(y), NewPixel(bloomColour))
-			buf.SetPixel(int(r.Pos.X+r.w)+dist, int(y), NewPixel(bloomColour))
-		}
-	}
-
-	// Draw rounded corner bloom
-	drawCorner := func(bbox *Rect, origin Vec) {
-		// Iterate over every pixel in the bounding box
-		for x := bbox.Pos.X; x <= bbox.Pos.X+bbox.w; x++ {
-			for y := bbox.Pos.Y; y <= bbox.Pos.Y+bbox.h; y++ {
-				dist := Dist(origin, Vec{x, y})
-				withinCircle := dist > r.radius && dist <= r.radius+bloom
-				if withinCircle {
-					// Calculate colour from distance away from shape body
-					brightness := 1 - (dist-r.radius)/(bloom)
-					r, g, b, a := RGBA8(r.style.Colour)
-
-					bloomColour := color.RGBA{r, g, b, uint8(brightness * float64(a))}
-					buf.SetPixel(int(math.Round(x)), int(math.Round(y)), NewPixel(bloomColour))
-				}
-			}
-		}
-	}
-
-	// Top left
-	bboxSize := bloom + r.radius
-	bbox := NewRect(bboxSize, bboxSize, Vec{r.Pos.X - bloom, r.Pos.Y - bloom})
-	drawCorner(bbox, Vec{bbox.Pos.X + bbox.w, bbox.Pos.Y + bbox.h})
-
-	// Top right
-	bbox = NewRect(bboxSize, bboxSize, Vec{r.Pos.X + r.w - r.radius, r.Pos.Y - bloom})
-	drawCorner(bbox, Vec{bbox.Pos.X, bbox.Pos.Y + bbox.h})
-
-	// Bottom left
-	bbox = NewRect(bboxSize, bboxSize, Vec{r.Pos.X - bloom, r.Pos.Y + r.h - r.radius})
-	drawCorner(bbox, Vec{bbox.Pos.X + bbox.w, bbox.Pos.Y})
-
-	// Bottom right
-	bbox = NewRect(bboxSize, bboxSize, Vec{r.Pos.X + r.w - r.radius, r.Pos.Y + r.h - r.radius})
-	drawCorner(bbox, Vec{bbox.Pos.X, bbox.Pos.Y})
-}
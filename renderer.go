@@ -0,0 +1,127 @@
+package gogl
+
+import (
+	"image"
+	"runtime"
+	"sync"
+)
+
+// Renderer draws a queue of drawables onto a frame buffer, in order.
+type Renderer interface {
+	// Render draws each drawable in the queue onto the frame buffer.
+	Render(buf *FrameBuffer, queue []Drawable)
+}
+
+// SequentialRenderer draws each drawable in turn on the calling goroutine.
+type SequentialRenderer struct{}
+
+var _ Renderer = SequentialRenderer{}
+
+// Render implements Renderer.
+func (SequentialRenderer) Render(buf *FrameBuffer, queue []Drawable) {
+	for _, d := range queue {
+		d.Draw(buf)
+	}
+}
+
+// bounded is a drawable which reports the region of pixels it can draw to.
+type bounded interface {
+	Drawable
+	Bounds() image.Rectangle
+}
+
+// ParallelRenderer splits the frame buffer into square tiles which are drawn
+// concurrently. Each drawable is drawn onto every tile its bounding box overlaps, with
+// drawing confined to the tile, so each pixel is drawn by one goroutine in queue order.
+// The output is identical to SequentialRenderer.
+//
+// Only drawables with a Bounds() image.Rectangle method are drawn in parallel, and
+// their Draw methods must be safe to call concurrently. Other drawables are drawn on
+// the whole frame buffer once every drawable before them has finished.
+type ParallelRenderer struct {
+	TileSize int // width and height of each tile, in pixels; 64 if 0
+	Workers  int // maximum number of tiles drawn at once; GOMAXPROCS if 0
+}
+
+var _ Renderer = ParallelRenderer{}
+
+// Render implements Renderer.
+func (r ParallelRenderer) Render(buf *FrameBuffer, queue []Drawable) {
+	// Draw runs of bounded drawables in parallel, with the others acting as barriers
+	start := 0
+	for i, d := range queue {
+		if _, ok := d.(bounded); ok {
+			continue
+		}
+		r.renderTiles(buf, queue[start:i])
+		d.Draw(buf)
+		start = i + 1
+	}
+	r.renderTiles(buf, queue[start:])
+}
+
+// renderTiles draws bounded drawables onto the frame buffer in parallel, one tile per
+// goroutine.
+func (r ParallelRenderer) renderTiles(buf *FrameBuffer, queue []Drawable) {
+	if len(queue) == 0 {
+		return
+	}
+
+	tileSize := r.TileSize
+	if tileSize <= 0 {
+		tileSize = 64
+	}
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Bin each drawable into the tiles that its bounds overlap, preserving queue order
+	area := buf.clip
+	cols := (area.Dx() + tileSize - 1) / tileSize
+	rows := (area.Dy() + tileSize - 1) / tileSize
+	bins := make([][]Drawable, cols*rows)
+	for _, d := range queue {
+		b := d.(bounded).Bounds().Intersect(area)
+		if b.Empty() {
+			continue
+		}
+		b = b.Sub(area.Min)
+		for ty := b.Min.Y / tileSize; ty <= (b.Max.Y-1)/tileSize; ty++ {
+			for tx := b.Min.X / tileSize; tx <= (b.Max.X-1)/tileSize; tx++ {
+				bins[tx+cols*ty] = append(bins[tx+cols*ty], d)
+			}
+		}
+	}
+
+	tiles := make(chan int, len(bins))
+	for i, bin := range bins {
+		if len(bin) > 0 {
+			tiles <- i
+		}
+	}
+	close(tiles)
+
+	views := make([]*FrameBuffer, len(bins))
+	var wg sync.WaitGroup
+	for range min(workers, len(tiles)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tiles {
+				origin := area.Min.Add(image.Pt(i%cols, i/cols).Mul(tileSize))
+				views[i] = buf.clipView(image.Rectangle{origin, origin.Add(image.Pt(tileSize, tileSize))})
+				for _, d := range bins[i] {
+					d.Draw(views[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, view := range views {
+		if view != nil {
+			buf.mergeDirty(view)
+		}
+	}
+}
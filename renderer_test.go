@@ -0,0 +1,102 @@
+package gogl
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+// randomScene constructs a deterministic queue of overlapping translucent shapes.
+func randomScene(n, width, height int) []Drawable {
+	rng := rand.New(rand.NewSource(1))
+	randVec := func() Vec {
+		return Vec{rng.Float64() * float64(width), rng.Float64() * float64(height)}
+	}
+	randStyle := func() Style {
+		return Style{
+			Colour: color.RGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256))},
+			Blend:  []BlendFunc{AlphaBlend, AdditiveBlend, MultiplyBlend}[rng.Intn(3)],
+		}
+	}
+
+	queue := make([]Drawable, 0, n)
+	for i := range n {
+		switch i % 7 {
+		case 0:
+			queue = append(queue, NewCircle(rng.Float64()*60, randVec()).SetStyle(randStyle()))
+		case 1:
+			queue = append(queue, NewRect(rng.Float64()*60, rng.Float64()*60, randVec()).SetStyle(randStyle()))
+		case 2:
+			queue = append(queue, NewCurvedRect(40, 30, 8, randVec()).SetStyle(randStyle()))
+		case 3:
+			queue = append(queue, NewEllipse(rng.Float64()*60, rng.Float64()*30, randVec()).SetStyle(randStyle()))
+		case 4:
+			queue = append(queue, NewTriangle(randVec(), randVec(), randVec()).SetStyle(randStyle()))
+		case 5:
+			p := randVec()
+			queue = append(queue, NewPolygon([]Vec{p, Add(p, Vec{30, 5}), Add(p, Vec{20, 40}), Add(p, Vec{-10, 20})}).SetStyle(randStyle()))
+		case 6:
+			queue = append(queue, NewText("tiles", randVec(), "fonts/luxisr.ttf").SetStyle(randStyle()))
+		}
+	}
+	return queue
+}
+
+func TestParallelRenderer(t *testing.T) {
+	const width, height = 203, 157
+	queue := randomScene(140, width, height)
+
+	// A drawable without bounds must be drawn once everything before it has finished
+	queue = append(queue[:70:70], append([]Drawable{barrierFunc(func(buf *FrameBuffer) {
+		for x := range width {
+			buf.SetPixel(x, 80, NewPixel(color.RGBA{0, 255, 0, 128}))
+		}
+	})}, queue[70:]...)...)
+
+	want := NewFrameBuffer(width, height)
+	want.Fill(Black)
+	want.PushClip(image.Rect(5, 3, 190, 150))
+	SequentialRenderer{}.Render(want, queue)
+
+	for _, r := range []ParallelRenderer{
+		{},
+		{TileSize: 16, Workers: 3},
+		{TileSize: 13, Workers: 8},
+		{TileSize: 500},
+	} {
+		got := NewFrameBuffer(width, height)
+		got.Fill(Black)
+		got.PushClip(image.Rect(5, 3, 190, 150))
+		r.Render(got, queue)
+
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("%+v: output differs from sequential renderer", r)
+		}
+	}
+}
+
+// barrierFunc is a drawable without bounds.
+type barrierFunc func(buf *FrameBuffer)
+
+func (f barrierFunc) Draw(buf *FrameBuffer) { f(buf) }
+
+func BenchmarkRenderer(b *testing.B) {
+	queue := randomScene(500, 1024, 768)
+	buf := NewFrameBuffer(1024, 768)
+
+	for _, tc := range []struct {
+		name     string
+		renderer Renderer
+	}{
+		{"Sequential", SequentialRenderer{}},
+		{"Parallel", ParallelRenderer{}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				tc.renderer.Render(buf, queue)
+			}
+		})
+	}
+}
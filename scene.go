@@ -0,0 +1,236 @@
+package gogl
+
+import (
+	"cmp"
+	"image"
+	"slices"
+)
+
+// Node is a node in a scene graph. A node can hold a drawable, and any number of child
+// nodes which are drawn along with it. A node's transform, visibility and opacity are
+// passed down to its children, so that a group of drawables can be moved, faded and
+// hidden as one.
+//
+// Opacity is applied to each drawable separately, so overlapping children show through
+// each other when their parent is faded.
+type Node struct {
+	drawable  Drawable
+	parent    *Node
+	children  []*Node
+	transform Transform
+	visible   bool
+	opacity   float64
+	z         int
+	offscreen offscreen // used to draw drawables which can't be transformed
+}
+
+// NewNode constructs a visible node which holds a drawable. The drawable may be nil, for
+// a node which only groups its children.
+func NewNode(d Drawable) *Node {
+	return &Node{
+		drawable:  d,
+		transform: IdentityTransform,
+		visible:   true,
+		opacity:   1,
+	}
+}
+
+// Drawable returns the node's drawable, or nil if it doesn't have one.
+func (n *Node) Drawable() Drawable {
+	return n.drawable
+}
+
+// SetDrawable sets the node's drawable. It may be nil.
+func (n *Node) SetDrawable(d Drawable) *Node {
+	n.drawable = d
+	return n
+}
+
+// Add adds child nodes to the node. Children which already have a parent are moved from
+// it. It panics if a child is the node itself or one of its ancestors.
+func (n *Node) Add(children ...*Node) *Node {
+	for _, child := range children {
+		for a := n; a != nil; a = a.parent {
+			if a == child {
+				panic("scene graph node can't be added to itself or its descendants")
+			}
+		}
+		if child.parent != nil {
+			child.parent.Remove(child)
+		}
+		child.parent = n
+		n.children = append(n.children, child)
+	}
+	return n
+}
+
+// Remove removes a child node from the node. It does nothing if the node isn't a child.
+func (n *Node) Remove(child *Node) *Node {
+	if i := slices.Index(n.children, child); i >= 0 {
+		n.children = slices.Delete(n.children, i, i+1)
+		child.parent = nil
+	}
+	return n
+}
+
+// Children returns the node's children, in the order they were added.
+func (n *Node) Children() []*Node {
+	return slices.Clone(n.children)
+}
+
+// Parent returns the node's parent, or nil if it doesn't have one.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Transform returns the node's transform, relative to its parent.
+func (n *Node) Transform() Transform {
+	return n.transform
+}
+
+// SetTransform sets the node's transform, relative to its parent. It's applied to the
+// node's drawable after the drawable's own transform.
+func (n *Node) SetTransform(t Transform) *Node {
+	n.transform = t
+	return n
+}
+
+// Move moves the node by a vector, in its parent's coordinates.
+func (n *Node) Move(v Vec) *Node {
+	n.transform = Translate(v).Mul(n.transform)
+	return n
+}
+
+// WorldTransform returns the transform from the node's coordinates to the coordinates of
+// the root of the scene graph.
+func (n *Node) WorldTransform() Transform {
+	m := n.transform
+	for a := n.parent; a != nil; a = a.parent {
+		m = a.transform.Mul(m)
+	}
+	return m
+}
+
+// Visible returns whether the node is shown. A visible node is still hidden if one of its
+// ancestors is hidden.
+func (n *Node) Visible() bool {
+	return n.visible
+}
+
+// SetVisible shows or hides the node and its children.
+func (n *Node) SetVisible(visible bool) *Node {
+	n.visible = visible
+	return n
+}
+
+// Opacity returns the node's opacity, from 0 to 1.
+func (n *Node) Opacity() float64 {
+	return n.opacity
+}
+
+// SetOpacity sets the node's opacity, from 0 to 1. It multiplies the opacity of the
+// node's drawable and its children.
+func (n *Node) SetOpacity(opacity float64) *Node {
+	n.opacity = Clamp(opacity, 0, 1)
+	return n
+}
+
+// Z returns the node's z-index.
+func (n *Node) Z() int {
+	return n.z
+}
+
+// SetZ sets the node's z-index. Siblings are drawn in order of z-index, then in the order
+// they were added. Children with a negative z-index are drawn beneath their parent's
+// drawable, and the others above it.
+func (n *Node) SetZ(z int) *Node {
+	n.z = z
+	return n
+}
+
+// Draw draws the node and its children onto the provided frame buffer.
+func (n *Node) Draw(buf *FrameBuffer) {
+	for _, d := range n.flatten(IdentityTransform, 1, nil) {
+		d.Draw(buf)
+	}
+}
+
+// viewed returns the drawables of the node and its descendants, with a view transform
+// applied after the node's own and their opacity scaled.
+func (n *Node) viewed(view Transform, opacity float64) Drawable {
+	return drawableList(n.flatten(view, opacity, nil))
+}
+
+// flatten appends the drawables of the node and its descendants to a queue, in the order
+// they should be drawn. Each drawable is transformed and faded by the node's ancestors.
+func (n *Node) flatten(parent Transform, opacity float64, queue []Drawable) []Drawable {
+	opacity *= n.opacity
+	if !n.visible || opacity == 0 {
+		return queue
+	}
+	m := parent.Mul(n.transform)
+
+	children := slices.Clone(n.children)
+	slices.SortStableFunc(children, func(a, b *Node) int {
+		return cmp.Compare(a.z, b.z)
+	})
+	above, _ := slices.BinarySearchFunc(children, 0, func(c *Node, z int) int {
+		return cmp.Compare(c.z, z)
+	})
+
+	for _, child := range children[:above] {
+		queue = child.flatten(m, opacity, queue)
+	}
+	if d := n.drawable; d != nil {
+		switch v, ok := d.(viewable); {
+		case m == IdentityTransform && opacity == 1:
+			queue = append(queue, d)
+		case ok:
+			queue = append(queue, v.viewed(m, opacity))
+		default:
+			queue = append(queue, offscreenDrawable{n, d, m, opacity})
+		}
+	}
+	for _, child := range children[above:] {
+		queue = child.flatten(m, opacity, queue)
+	}
+	return queue
+}
+
+// offscreenDrawable draws the drawable of a node which can't be transformed, such as a
+// button. The drawable is drawn onto an offscreen frame buffer the same size as the target,
+// which is then transformed and faded onto the target.
+type offscreenDrawable struct {
+	node    *Node // holds the offscreen frame buffer between frames
+	d       Drawable
+	m       Transform
+	opacity float64
+}
+
+// viewed returns a copy of the drawable with a view transform applied after the node's,
+// and its opacity scaled.
+func (o offscreenDrawable) viewed(view Transform, opacity float64) Drawable {
+	o.m = view.Mul(o.m)
+	o.opacity *= opacity
+	return o
+}
+
+// Draw implements Drawable.
+func (o offscreenDrawable) Draw(buf *FrameBuffer) {
+	o.node.offscreen.draw(buf, o.d.Draw, func(off *FrameBuffer, area image.Rectangle) {
+		s := NewSprite(off, Vec{float64(area.Min.X), float64(area.Min.Y)}).SetSource(area)
+		s.style = s.style.faded(o.opacity)
+		s.transformable = s.withView(o.m, s.pos)
+		s.Draw(buf)
+	})
+}
+
+// drawableList is a list of drawables which are drawn in order.
+type drawableList []Drawable
+
+// Draw implements Drawable.
+func (l drawableList) Draw(buf *FrameBuffer) {
+	for _, d := range l {
+		d.Draw(buf)
+	}
+}
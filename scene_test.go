@@ -0,0 +1,117 @@
+package gogl
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNodeHierarchy(t *testing.T) {
+	root, group, leaf := NewNode(nil), NewNode(nil), NewNode(nil)
+	root.Add(group)
+	group.Add(leaf)
+	if leaf.Parent() != group || !reflect.DeepEqual(root.Children(), []*Node{group}) {
+		t.Error("Expected nodes to be linked to their parents")
+	}
+
+	group.SetTransform(Translate(Vec{10, 0}))
+	leaf.SetTransform(Scale(2, 2))
+	if got := leaf.WorldTransform().Apply(Vec{1, 1}); !vecNear(got, Vec{12, 2}) {
+		t.Errorf("Expected leaf to be scaled then moved, got %v", got)
+	}
+
+	// Adding a node to another parent moves it
+	root.Add(leaf)
+	if leaf.Parent() != root || len(group.Children()) != 0 {
+		t.Error("Expected leaf to be moved to the root")
+	}
+	root.Remove(leaf)
+	if leaf.Parent() != nil || len(root.Children()) != 1 {
+		t.Error("Expected leaf to be removed from the root")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected adding a node to its descendant to panic")
+		}
+	}()
+	group.Add(root)
+}
+
+func TestNodeDrawOrder(t *testing.T) {
+	var order []string
+	record := func(name string) *Node {
+		return NewNode(barrierFunc(func(*FrameBuffer) { order = append(order, name) }))
+	}
+
+	root := record("root")
+	root.Add(
+		record("a").SetZ(1),
+		record("b").SetZ(-1),
+		record("c").SetZ(1).Add(record("c child")),
+		record("d"),
+		record("hidden").SetVisible(false).Add(record("hidden child")),
+		record("faded").SetOpacity(0),
+	)
+	root.Draw(NewFrameBuffer(1, 1))
+
+	if want := []string{"b", "root", "d", "a", "c", "c child"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected draw order %v, got %v", want, order)
+	}
+}
+
+func TestNodeTransforms(t *testing.T) {
+	// Shapes are transformed and faded by every ancestor
+	rect := NewRect(2, 2, Vec{0, 0}).SetStyle(Style{Colour: White})
+	group := NewNode(nil).SetTransform(Translate(Vec{4, 0})).SetOpacity(0.5)
+	group.Add(NewNode(rect).SetTransform(Translate(Vec{0, 4})).SetOpacity(0.5))
+
+	f := NewFrameBuffer(8, 8)
+	group.Draw(f)
+	if got := f.GetPixel(0, 0); got != 0 {
+		t.Errorf("Expected rectangle to be moved, got %v", got)
+	}
+	if _, _, _, a := RGBA8(f.GetPixel(4, 4)); a != 64 {
+		t.Errorf("Expected rectangle to be drawn at quarter opacity, got alpha %d", a)
+	}
+
+	// Drawables which can't be transformed are drawn offscreen, then transformed
+	dot := barrierFunc(func(buf *FrameBuffer) { buf.SetPixel(5, 4, NewPixel(Red)) })
+	node := NewNode(dot).SetTransform(Rotate(math.Pi / 2).about(Vec{4, 4}))
+	f = NewFrameBuffer(8, 8)
+	for range 2 {
+		node.Draw(f)
+		if got := f.GetPixel(3, 5); got != NewPixel(Red) {
+			t.Errorf("Expected pixel to be rotated onto (3,5), got %v", got)
+		}
+		if got := f.GetPixel(5, 4); got != 0 {
+			t.Errorf("Expected original pixel not to be drawn, got %v", got)
+		}
+	}
+	if got := node.offscreen.buf.GetPixel(5, 4); got != 0 {
+		t.Errorf("Expected offscreen frame buffer to be cleared, got %v", got)
+	}
+}
+
+func TestWindowScene(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 10, 10)
+
+	// The scene is drawn beneath the queued shapes
+	root := NewNode(NewRect(10, 10, Vec{0, 0}).SetStyle(Style{Colour: Red}))
+	win.SetScene(root)
+	win.Draw(NewRect(5, 5, Vec{0, 0}).SetStyle(Style{Colour: Blue}))
+	win.Update()
+	if got := backend.Frame().GetPixel(2, 2); got != NewPixel(Blue) {
+		t.Errorf("Expected queued shape to be drawn over the scene, got %v", got)
+	}
+	if got := backend.Frame().GetPixel(7, 7); got != NewPixel(Red) {
+		t.Errorf("Expected scene to be drawn, got %v", got)
+	}
+
+	// The scene is drawn again on every frame
+	root.SetDrawable(NewRect(10, 10, Vec{0, 0}).SetStyle(Style{Colour: Lime}))
+	win.Update()
+	if got := backend.Frame().GetPixel(2, 2); got != NewPixel(Lime) {
+		t.Errorf("Expected scene to be redrawn, got %v", got)
+	}
+}
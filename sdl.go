@@ -0,0 +1,149 @@
+//go:build !nosdl
+
+package gogl
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/img"
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// sdlBackend is a backend which renders to an OS window using SDL.
+type sdlBackend struct {
+	win      *sdl.Window
+	renderer *sdl.Renderer
+	texture  *sdl.Texture
+}
+
+var _ Backend = (*sdlBackend)(nil)
+
+// newSDLBackend constructs a new SDL backend. Init must be called before use.
+func newSDLBackend() *sdlBackend {
+	return &sdlBackend{}
+}
+
+// Init initialises SDL and creates the window, renderer and texture.
+func (s *sdlBackend) Init(cfg WindowCfg) error {
+	if ok := sdl.Init(sdl.InitVideo); !ok {
+		return fmt.Errorf("failed to init sdl3: %s", sdl.GetError())
+	}
+
+	var resizableFlag3 sdl.WindowFlags
+	if cfg.Resizable {
+		resizableFlag3 = sdl.WindowResizable
+	}
+
+	s.win = sdl.CreateWindow(
+		cfg.Title,
+		int32(cfg.Width),
+		int32(cfg.Height),
+		resizableFlag3,
+	)
+	if s.win == nil {
+		return fmt.Errorf("failed to create sdl3 window: %s", sdl.GetError())
+	}
+	if !sdl.StartTextInput(s.win) {
+		return fmt.Errorf("failed to start text input: %s", sdl.GetError())
+	}
+
+	s.renderer = sdl.CreateRenderer(s.win, "")
+	if s.renderer == nil {
+		return fmt.Errorf("failed to create sdl3 renderer: %s", sdl.GetError())
+	}
+
+	s.texture = sdl.CreateTexture(
+		s.renderer,
+		sdl.PixelFormatRGBA8888,
+		sdl.TextureAccessStreaming,
+		int32(cfg.Width),
+		int32(cfg.Height),
+	)
+	if s.texture == nil {
+		return fmt.Errorf("failed to create sdl3 texture: %s", sdl.GetError())
+	}
+
+	// (Optional) set window icon
+	if cfg.Icon != nil {
+		iconSurface := img.Load(cfg.Icon.Name())
+		sdl.SetWindowIcon(s.win, iconSurface)
+	}
+
+	return nil
+}
+
+// Destroy deallocates the SDL resources and shuts SDL down.
+func (s *sdlBackend) Destroy() {
+	sdl.DestroyTexture(s.texture)
+	sdl.DestroyRenderer(s.renderer)
+	sdl.DestroyWindow(s.win)
+	sdl.Quit()
+}
+
+// PollEvent returns the next SDL event that gogl is interested in.
+func (s *sdlBackend) PollEvent() (Event, bool) {
+	var event sdl.Event
+	for sdl.PollEvent(&event) {
+		switch event.Type() {
+		case sdl.EventQuit:
+			return QuitEvent{}, true
+		case sdl.EventKeyDown, sdl.EventKeyUp:
+			e := event.Key()
+			return KeyEvent{Key: Keycode(e.Key), Down: e.Down, Repeat: e.Repeat}, true
+		case sdl.EventTextInput:
+			e := event.Text()
+			return TextInputEvent{Text: e.Text()}, true
+		case sdl.EventMouseWheel:
+			e := event.Wheel()
+			return MouseWheelEvent{Movement: Vec{float64(e.MouseX), float64(e.MouseY)}}, true
+		}
+	}
+	return nil, false
+}
+
+// Present uploads the dirty regions of the frame buffer to the SDL texture and renders
+// it to the window.
+func (s *sdlBackend) Present(buf *FrameBuffer, dirty []image.Rectangle) error {
+	for _, r := range dirty {
+		// Pitch is bytes between the start of each row (RGBA8888)
+		pixels, pitch := buf.regionBytes(r)
+		rect := sdl.Rect{X: int32(r.Min.X), Y: int32(r.Min.Y), W: int32(r.Dx()), H: int32(r.Dy())}
+		if !sdl.UpdateTexture(s.texture, &rect, unsafe.Pointer(&pixels[0]), int32(pitch)) {
+			return errors.New("failed to update texture: " + sdl.GetError())
+		}
+	}
+	if !sdl.RenderTexture(s.renderer, s.texture, nil, nil) {
+		return errors.New("failed to render texture: " + sdl.GetError())
+	}
+	if !sdl.RenderPresent(s.renderer) {
+		return errors.New("failed to present render: " + sdl.GetError())
+	}
+	return nil
+}
+
+// Mouse returns the location of the mouse cursor and the state of its buttons.
+func (s *sdlBackend) Mouse() (Vec, MouseState) {
+	var x, y float32
+	state := sdl.GetMouseState(&x, &y)
+	return Vec{X: float64(x), Y: float64(y)}, MouseState(state)
+}
+
+// Size returns the dimensions of the window, in pixels.
+func (s *sdlBackend) Size() (int, int) {
+	var width, height int32
+	if !sdl.GetWindowSize(s.win, &width, &height) {
+		fmt.Println("failed to get window size: " + sdl.GetError())
+	}
+	return int(width), int(height)
+}
+
+// SetTitle sets the title of the window.
+func (s *sdlBackend) SetTitle(title string) error {
+	if !sdl.SetWindowTitle(s.win, title) {
+		return errors.New("failed to set window title: " + sdl.GetError())
+	}
+	return nil
+}
@@ -10,15 +10,18 @@ import (
 // Style contains style information for a shape.
 type Style struct {
 	Colour    color.Color
-	Thickness float64 // leave 0 for solid
-	Bloom     int     // bloom reach, in pixels
+	Paint     Paint     // colours each pixel of the shape; Colour is used if nil
+	Thickness float64   // leave 0 for solid
+	Blend     BlendFunc // blends the shape onto the frame buffer; AlphaBlend if nil
+	Opacity   *float64  // from 0 to 1, scales the alpha of the whole shape; opaque if nil
+	AntiAlias bool      // smooths the edges of the shape
 }
 
 // DefaultStyle is the default style for new shapes.
 var DefaultStyle = Style{
 	Colour:    color.RGBA{0xff, 0xff, 0xff, 0xff},
 	Thickness: 0,
-	Bloom:     0,
+	Blend:     AlphaBlend,
 }
 
 // RandomStyle generates a style of random colour and thickness 0.
@@ -31,10 +34,41 @@ func RandomStyle() Style {
 			A: byte(rand.Intn(256)),
 		},
 		Thickness: 0,
-		Bloom:     0,
+		Blend:     AlphaBlend,
 	}
 }
 
+// solid returns a copy of the style for drawing the solid parts of a shape, without
+// thickness.
+func (s Style) solid() Style {
+	s.Thickness = 0
+	return s
+}
+
+// faded returns a copy of the style with its opacity scaled.
+func (s Style) faded(opacity float64) Style {
+	faded := s.opacity() * opacity
+	s.Opacity = &faded
+	return s
+}
+
+// blendFunc returns the style's blend function, defaulting to AlphaBlend.
+func (s Style) blendFunc() BlendFunc {
+	if s.Blend == nil {
+		return AlphaBlend
+	}
+	return s.Blend
+}
+
+// opacity returns the style's opacity, clamped between 0 and 1. An unset opacity is
+// treated as fully opaque.
+func (s Style) opacity() float64 {
+	if s.Opacity == nil {
+		return 1
+	}
+	return Clamp(*s.Opacity, 0, 1)
+}
+
 // Shape is an interface for shapes.
 type Shape interface {
 	Drawable
@@ -0,0 +1,42 @@
+package gogl
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestStyleOpacity(t *testing.T) {
+	for name, style := range map[string]Style{
+		"transparent": {Colour: White, Opacity: new(0.0)},
+		"faded":       Style{Colour: White, Opacity: new(0.5)}.faded(0),
+	} {
+		f := NewFrameBuffer(8, 8)
+		NewRect(4, 4, Vec{2, 2}).SetStyle(style).Draw(f)
+		if got := f.GetPixel(4, 4); got != 0 {
+			t.Errorf("Expected %s rectangle not to be drawn, got %v", name, got)
+		}
+	}
+	if got := (Style{}).faded(0.5).opacity(); got != 0.5 {
+		t.Errorf("Expected unset opacity to be faded from 1, got %f", got)
+	}
+}
+
+func TestTranslucentShapes(t *testing.T) {
+	// Shapes made of overlapping parts should blend each pixel only once
+	style := Style{Colour: color.RGBA{255, 0, 0, 128}}
+	for name, shape := range map[string]Drawable{
+		"outlined rectangle": NewRect(20, 20, Vec{2, 2}).SetStyle(Style{Colour: style.Colour, Thickness: 12}),
+		"curved rectangle":   NewCurvedRect(20, 20, 6, Vec{2, 2}).SetStyle(style),
+		"polygon":            NewPolygon([]Vec{{2, 2}, {22, 2}, {22, 22}, {12, 16}, {2, 22}}).SetStyle(style),
+	} {
+		f := NewFrameBuffer(26, 26)
+		shape.Draw(f)
+		for y := range f.Height() {
+			for x := range f.Width() {
+				if a := f.GetPixel(x, y).A(); a != 0 && a != 128 {
+					t.Errorf("Expected %s to blend pixel (%d,%d) once, got alpha %d", name, x, y, a)
+				}
+			}
+		}
+	}
+}
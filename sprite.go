@@ -0,0 +1,356 @@
+package gogl
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"io/fs"
+	"math"
+)
+
+// Sprite is an image drawn from a frame buffer, which can be scaled, rotated and flipped.
+// Only part of the frame buffer may be drawn, so that many sprites can share one sprite
+// sheet. The drawn region is normally the whole of the sprite's frame, but the frames of
+// an AnimatedSprite may have been trimmed of their transparent edges.
+//
+// The sprite's style colour tints it by multiplying each pixel; white leaves the image
+// unchanged. The style's blend function and opacity are also used.
+type Sprite struct {
+	image    *FrameBuffer
+	src      image.Rectangle // region of the image to draw
+	trim     Vec             // offset of the region within the frame
+	size     Vec             // size of the frame, which may be larger than the region
+	pos      Vec             // position of the pivot
+	pivot    Vec             // offset from the top left of the frame
+	scale    Vec
+	rotation float64 // radians, clockwise on screen about the pivot
+	flipX    bool
+	flipY    bool
+	filter   Filter
+	style    Style
+	transformable
+}
+
+var _ Shape = (*Sprite)(nil)
+
+// NewSprite constructs a new sprite which draws the whole of an image, with its top left
+// corner at pos.
+func NewSprite(img *FrameBuffer, pos Vec) *Sprite {
+	s := &Sprite{
+		image: img,
+		pos:   pos,
+		scale: Vec{1, 1},
+		style: DefaultStyle,
+	}
+	return s.SetSource(img.Bounds())
+}
+
+// DecodeSprite decodes a PNG, JPEG or GIF image into a new sprite at the origin.
+func DecodeSprite(r io.Reader) (*Sprite, error) {
+	img, err := DecodeFrameBuffer(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewSprite(img, Vec{}), nil
+}
+
+// LoadSprite loads a PNG, JPEG or GIF image file into a new sprite at the origin.
+func LoadSprite(path string) (*Sprite, error) {
+	img, err := LoadFrameBuffer(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSprite(img, Vec{}), nil
+}
+
+// LoadSpriteFS loads a PNG, JPEG or GIF image file from a file system, such as an
+// embed.FS, into a new sprite at the origin.
+func LoadSpriteFS(fsys fs.FS, path string) (*Sprite, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sprite, err := DecodeSprite(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return sprite, nil
+}
+
+// Draw draws the sprite onto the provided frame buffer.
+func (s *Sprite) Draw(buf *FrameBuffer) {
+	area := s.Bounds().Intersect(buf.clip)
+	if area.Empty() || s.src.Empty() || s.scale.X == 0 || s.scale.Y == 0 {
+		return
+	}
+
+	inv, ok := s.matrix(s.pos).Invert()
+	if !ok {
+		return
+	}
+
+	convert := buf.convertFrom(s.image)
+	tint := tinter(s.style.Colour, s.style.opacity(), buf.premultiplied)
+	blend := buf.nativeBlend(s.style.blendFunc())
+	w, h := float64(s.src.Dx()), float64(s.src.Dy())
+	origin := Sub(Vec{float64(s.src.Min.X), float64(s.src.Min.Y)}, s.trim)
+
+	buf.MarkDirty(area)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		row := buf.row(y)
+		for x := area.Min.X; x < area.Max.X; x++ {
+			// Map the centre of each pixel back into the source region
+			local := s.toLocal(inv.Apply(Vec{float64(x) + 0.5, float64(y) + 0.5}))
+			if local.X < s.trim.X || local.Y < s.trim.Y || local.X >= s.trim.X+w || local.Y >= s.trim.Y+h {
+				continue
+			}
+			p := s.image.sample(origin.X+local.X, origin.Y+local.Y, s.src, s.filter)
+			if convert != nil {
+				p = convert(p)
+			}
+			if tint != nil {
+				p = tint(p)
+			}
+			row[x] = blend(p, row[x])
+		}
+	}
+}
+
+// toLocal maps a point on the frame buffer, before the sprite's transform is applied, to
+// a point within the frame, relative to its top left corner.
+func (s *Sprite) toLocal(p Vec) Vec {
+	p = Sub(p, s.pos).Rotate(s.rotation)
+	p = Add(Vec{p.X / s.scale.X, p.Y / s.scale.Y}, s.pivot)
+	if s.flipX {
+		p.X = s.size.X - p.X
+	}
+	if s.flipY {
+		p.Y = s.size.Y - p.Y
+	}
+	return p
+}
+
+// fromLocal maps a point within the frame, relative to its top left corner, to a point
+// on the frame buffer.
+func (s *Sprite) fromLocal(p Vec) Vec {
+	if s.flipX {
+		p.X = s.size.X - p.X
+	}
+	if s.flipY {
+		p.Y = s.size.Y - p.Y
+	}
+	p = Sub(p, s.pivot)
+	p = Vec{p.X * s.scale.X, p.Y * s.scale.Y}.Rotate(-s.rotation)
+	return s.matrix(s.pos).Apply(Add(p, s.pos))
+}
+
+// Bounds returns the pixel bounding box of the sprite after it has been transformed.
+func (s *Sprite) Bounds() image.Rectangle {
+	minP, maxP := s.trim, Add(s.trim, Vec{float64(s.src.Dx()), float64(s.src.Dy())})
+	minV, maxV := pointExtent([]Vec{
+		s.fromLocal(minP),
+		s.fromLocal(Vec{maxP.X, minP.Y}),
+		s.fromLocal(maxP),
+		s.fromLocal(Vec{minP.X, maxP.Y}),
+	})
+	return pixelBounds(minV.X, minV.Y, maxV.X, maxV.Y)
+}
+
+// Width returns the width of the sprite's frame in pixels, after scaling.
+func (s *Sprite) Width() float64 {
+	return math.Abs(s.size.X * s.scale.X)
+}
+
+// Height returns the height of the sprite's frame in pixels, after scaling.
+func (s *Sprite) Height() float64 {
+	return math.Abs(s.size.Y * s.scale.Y)
+}
+
+// GetPos returns the position of the sprite's pivot.
+func (s *Sprite) GetPos() Vec {
+	return s.pos
+}
+
+// SetPos sets the position of the sprite's pivot.
+func (s *Sprite) SetPos(pos Vec) {
+	s.pos = pos
+}
+
+// Move moves the sprite by the given vector.
+func (s *Sprite) Move(px Vec) {
+	s.pos = Add(s.pos, px)
+}
+
+// GetStyle returns the sprite's style.
+func (s *Sprite) GetStyle() Style {
+	return s.style
+}
+
+// SetStyle sets the style of the sprite. Thickness and paint are ignored.
+func (s *Sprite) SetStyle(style Style) *Sprite {
+	s.style = style
+	return s
+}
+
+// Image returns the frame buffer which the sprite is drawn from.
+func (s *Sprite) Image() *FrameBuffer {
+	return s.image
+}
+
+// SetImage sets the frame buffer which the sprite is drawn from, and draws the whole of
+// it.
+func (s *Sprite) SetImage(img *FrameBuffer) *Sprite {
+	s.image = img
+	return s.SetSource(img.Bounds())
+}
+
+// Source returns the region of the image which is drawn.
+func (s *Sprite) Source() image.Rectangle {
+	return s.src
+}
+
+// SetSource sets the region of the image which is drawn, such as a frame of a sprite
+// sheet. The region is limited to the bounds of the image.
+func (s *Sprite) SetSource(r image.Rectangle) *Sprite {
+	s.src = r.Intersect(s.image.Bounds())
+	s.trim = Vec{}
+	s.size = Vec{float64(s.src.Dx()), float64(s.src.Dy())}
+	return s
+}
+
+// setFrame sets the region of the image which is drawn, where the region has been trimmed
+// from a larger frame. The offset is the position of the region within the frame.
+func (s *Sprite) setFrame(r image.Rectangle, offset, size image.Point) {
+	s.SetSource(r)
+	s.trim = Vec{float64(offset.X), float64(offset.Y)}
+	s.size = Vec{float64(size.X), float64(size.Y)}
+}
+
+// Pivot returns the point which the sprite is positioned and rotated about, relative to
+// the top left of its frame, in unscaled pixels.
+func (s *Sprite) Pivot() Vec {
+	return s.pivot
+}
+
+// SetPivot sets the point which the sprite is positioned and rotated about, relative to
+// the top left of its frame, in unscaled pixels.
+func (s *Sprite) SetPivot(pivot Vec) *Sprite {
+	s.pivot = pivot
+	return s
+}
+
+// CentrePivot sets the sprite's pivot to the centre of its frame.
+func (s *Sprite) CentrePivot() *Sprite {
+	s.pivot = Vec{s.size.X / 2, s.size.Y / 2}
+	return s
+}
+
+// Scale returns the horizontal and vertical scale of the sprite.
+func (s *Sprite) Scale() Vec {
+	return s.scale
+}
+
+// SetScale sets the horizontal and vertical scale of the sprite.
+func (s *Sprite) SetScale(scale Vec) *Sprite {
+	s.scale = scale
+	return s
+}
+
+// Rotation returns the rotation of the sprite about its pivot, in radians clockwise on
+// screen.
+func (s *Sprite) Rotation() float64 {
+	return s.rotation
+}
+
+// SetRotation sets the rotation of the sprite about its pivot, in radians clockwise on
+// screen.
+func (s *Sprite) SetRotation(theta float64) *Sprite {
+	s.rotation = theta
+	return s
+}
+
+// Flip returns whether the sprite is flipped horizontally and vertically.
+func (s *Sprite) Flip() (x, y bool) {
+	return s.flipX, s.flipY
+}
+
+// SetFlip sets whether the sprite is flipped horizontally and vertically. The image is
+// flipped within its frame, so the pivot stays in place.
+func (s *Sprite) SetFlip(x, y bool) *Sprite {
+	s.flipX, s.flipY = x, y
+	return s
+}
+
+// Tint returns the colour which the sprite is tinted.
+func (s *Sprite) Tint() color.Color {
+	return s.style.Colour
+}
+
+// SetTint sets the colour which the sprite is tinted, by multiplying each pixel. White
+// leaves the image unchanged.
+func (s *Sprite) SetTint(c color.Color) *Sprite {
+	s.style.Colour = c
+	return s
+}
+
+// SetTransform sets a transform which is applied about the sprite's pivot, after its
+// scale, rotation and flip.
+func (s *Sprite) SetTransform(t Transform) *Sprite {
+	s.setTransform(t)
+	return s
+}
+
+// viewed returns a copy of the sprite with a view transform applied after its own,
+// and its opacity scaled.
+func (s *Sprite) viewed(view Transform, opacity float64) Drawable {
+	v := *s
+	v.transformable = s.withView(view, s.pos)
+	v.style = v.style.faded(opacity)
+	return &v
+}
+
+// Filter returns the filter used when the sprite is scaled or rotated.
+func (s *Sprite) Filter() Filter {
+	return s.filter
+}
+
+// SetFilter sets the filter used when the sprite is scaled or rotated.
+func (s *Sprite) SetFilter(filter Filter) *Sprite {
+	s.filter = filter
+	return s
+}
+
+// String returns the name of the shape.
+func (s *Sprite) String() string {
+	return "sprite"
+}
+
+// tinter returns a function which multiplies pixels by a colour with straight alpha and
+// an opacity, or nil if it would leave them unchanged. Pixels are in the given alpha
+// format.
+func tinter(c color.Color, opacity float64, premultiplied bool) func(Pixel) Pixel {
+	var r, g, b, a uint8 = math.MaxUint8, math.MaxUint8, math.MaxUint8, math.MaxUint8
+	if c != nil {
+		r, g, b, a = RGBA8(c)
+	}
+	alpha := float64(a) / math.MaxUint8 * opacity
+	if r == math.MaxUint8 && g == math.MaxUint8 && b == math.MaxUint8 && alpha == 1 {
+		return nil
+	}
+
+	colour := [3]float64{float64(r) / math.MaxUint8, float64(g) / math.MaxUint8, float64(b) / math.MaxUint8}
+	if premultiplied {
+		for i := range colour {
+			colour[i] *= alpha
+		}
+	}
+	mul := func(v uint8, f float64) uint8 {
+		return uint8(math.Round(float64(v) * f))
+	}
+	return func(p Pixel) Pixel {
+		return pack(mul(p.A(), alpha), mul(p.B(), colour[2]), mul(p.G(), colour[1]), mul(p.R(), colour[0]))
+	}
+}
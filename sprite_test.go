@@ -0,0 +1,122 @@
+package gogl
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+// testSprite returns a sprite of a 2x2 image with a different colour in each pixel.
+func testSprite() *Sprite {
+	img := NewFrameBuffer(2, 2)
+	img.SetPixel(0, 0, NewPixel(Red))
+	img.SetPixel(1, 0, NewPixel(Lime))
+	img.SetPixel(0, 1, NewPixel(Blue))
+	img.SetPixel(1, 1, NewPixel(White))
+	return NewSprite(img, Vec{2, 3})
+}
+
+func TestSpriteTransforms(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		sprite *Sprite
+		want   map[image.Point]color.Color
+	}{
+		{
+			"plain",
+			testSprite(),
+			map[image.Point]color.Color{{2, 3}: Red, {3, 3}: Lime, {2, 4}: Blue, {3, 4}: White, {4, 3}: Black},
+		},
+		{
+			"source",
+			testSprite().SetSource(image.Rect(1, 0, 2, 2)),
+			map[image.Point]color.Color{{2, 3}: Lime, {2, 4}: White, {3, 3}: Black},
+		},
+		{
+			"scale",
+			testSprite().SetScale(Vec{2, 1}),
+			map[image.Point]color.Color{{2, 3}: Red, {3, 3}: Red, {4, 3}: Lime, {5, 4}: White},
+		},
+		{
+			"flip",
+			testSprite().SetFlip(true, true),
+			map[image.Point]color.Color{{2, 3}: White, {3, 3}: Blue, {2, 4}: Lime, {3, 4}: Red},
+		},
+		{
+			"rotate",
+			testSprite().CentrePivot().SetRotation(math.Pi / 2),
+			map[image.Point]color.Color{{1, 2}: Blue, {2, 2}: Red, {1, 3}: White, {2, 3}: Lime},
+		},
+		{
+			"tint",
+			testSprite().SetTint(color.RGBA{255, 0, 255, 255}),
+			map[image.Point]color.Color{{2, 3}: Red, {3, 3}: Black, {3, 4}: Magenta},
+		},
+	} {
+		for _, f := range []*FrameBuffer{NewFrameBuffer(8, 8), NewPremultipliedFrameBuffer(8, 8)} {
+			f.Fill(Black)
+			tc.sprite.Draw(f)
+			for pt, c := range tc.want {
+				if got := f.GetPixel(pt.X, pt.Y); got != NewPixel(c) {
+					t.Errorf("%s, premultiplied %v: expected pixel %v to be %v, got %v",
+						tc.name, f.IsPremultiplied(), pt, NewPixel(c), got)
+				}
+			}
+		}
+	}
+}
+
+func TestSpriteOpacity(t *testing.T) {
+	img := NewFrameBuffer(1, 1)
+	img.SetPixel(0, 0, NewPixel(color.RGBA{255, 255, 255, 128}))
+
+	for _, f := range []*FrameBuffer{NewFrameBuffer(1, 1), NewPremultipliedFrameBuffer(1, 1)} {
+		NewSprite(img, Vec{}).SetStyle(Style{Colour: White, Opacity: new(0.5)}).Draw(f)
+		if got := f.GetPixel(0, 0); got.A() != 64 {
+			t.Errorf("Premultiplied %v: expected alpha 64, got %v", f.IsPremultiplied(), got.A())
+		}
+	}
+}
+
+func TestSpriteBounds(t *testing.T) {
+	s := NewSprite(NewFrameBuffer(10, 4), Vec{20, 20}).CentrePivot().SetScale(Vec{2, 2})
+	if s.Width() != 20 || s.Height() != 8 {
+		t.Errorf("Expected size 20x8, got %vx%v", s.Width(), s.Height())
+	}
+	if want := pixelBounds(10, 16, 30, 24); s.Bounds() != want {
+		t.Errorf("Expected bounds %v, got %v", want, s.Bounds())
+	}
+
+	// A quarter turn swaps the width and height of the bounds
+	s.SetRotation(math.Pi / 2)
+	if b := s.Bounds(); b.Dx() != 8+3 || b.Dy() != 20+3 {
+		t.Errorf("Expected rotated bounds of 11x23, got %vx%v", b.Dx(), b.Dy())
+	}
+}
+
+func TestLoadSprite(t *testing.T) {
+	data, err := os.ReadFile("testdata/sprites.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{"sprites/sheet.png": {Data: data}}
+	s, err := LoadSpriteFS(fsys, "sprites/sheet.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Width() != 16 || s.Height() != 8 {
+		t.Errorf("Expected size 16x8, got %vx%v", s.Width(), s.Height())
+	}
+
+	if _, err := LoadSpriteFS(fsys, "missing.png"); err == nil {
+		t.Error("Expected an error loading a missing file")
+	}
+	if _, err := DecodeSprite(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Error("Expected an error decoding an invalid image")
+	}
+}
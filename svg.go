@@ -0,0 +1,948 @@
+package gogl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SVGImage is a vector image imported from an SVG document. It's drawn with its top left
+// corner at its position, and can be scaled to any size without losing detail.
+//
+// Only a basic subset of SVG is supported: rect, circle, ellipse, line, polyline,
+// polygon and path elements, inside groups. Shapes can be filled and stroked with plain
+// colours, and transformed. Other elements, such as text, gradients and images, are
+// ignored.
+type SVGImage struct {
+	pos       Vec
+	scale     Vec
+	width     float64 // width before scaling
+	height    float64 // height before scaling
+	antiAlias bool
+	shapes    []svgShape
+	paths     []*Path // shapes positioned and scaled for drawing
+	transformable
+}
+
+// svgShape is a shape from an SVG document, in the image's unscaled coordinates.
+type svgShape struct {
+	path   *Path
+	fill   *Style // nil if the shape isn't filled
+	stroke *Style // nil if the shape isn't stroked
+}
+
+// ParseSVG parses an SVG document into a vector image.
+func ParseSVG(r io.Reader) (*SVGImage, error) {
+	img, err := parseSVG(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SVG: %w", err)
+	}
+	return img, nil
+}
+
+// LoadSVG loads an SVG file into a vector image.
+func LoadSVG(path string) (*SVGImage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseSVG(file)
+}
+
+// Draw draws the image onto the provided frame buffer.
+func (s *SVGImage) Draw(buf *FrameBuffer) {
+	for _, p := range s.paths {
+		p.Draw(buf)
+	}
+}
+
+// Bounds returns the pixel bounding box of the image's shapes.
+func (s *SVGImage) Bounds() image.Rectangle {
+	var bounds image.Rectangle
+	for _, p := range s.paths {
+		bounds = bounds.Union(p.Bounds())
+	}
+	return bounds
+}
+
+// SVG returns the image as an SVG group element, at its current position and size.
+func (s *SVGImage) SVG() string {
+	var sb strings.Builder
+	sb.WriteString("<g>")
+	for _, p := range s.paths {
+		sb.WriteString(p.SVG())
+	}
+	sb.WriteString("</g>")
+	return sb.String()
+}
+
+// Width returns the width of the image in pixels, after scaling.
+func (s *SVGImage) Width() float64 {
+	return s.width * s.scale.X
+}
+
+// Height returns the height of the image in pixels, after scaling.
+func (s *SVGImage) Height() float64 {
+	return s.height * s.scale.Y
+}
+
+// SetSize scales the image to a width and height in pixels.
+func (s *SVGImage) SetSize(width, height float64) *SVGImage {
+	s.scale = Vec{width / s.width, height / s.height}
+	s.layout()
+	return s
+}
+
+// Scale returns the horizontal and vertical scale factors of the image.
+func (s *SVGImage) Scale() Vec {
+	return s.scale
+}
+
+// SetScale scales the image by a factor, relative to the size given in the document.
+func (s *SVGImage) SetScale(factor float64) *SVGImage {
+	s.scale = Vec{factor, factor}
+	s.layout()
+	return s
+}
+
+// GetPos returns the position of the top left corner of the image.
+func (s *SVGImage) GetPos() Vec {
+	return s.pos
+}
+
+// SetPos sets the position of the top left corner of the image.
+func (s *SVGImage) SetPos(pos Vec) *SVGImage {
+	s.pos = pos
+	s.layout()
+	return s
+}
+
+// Move moves the image by the given vector.
+func (s *SVGImage) Move(px Vec) {
+	s.SetPos(Add(s.pos, px))
+}
+
+// AntiAlias returns whether the image's edges are smoothed.
+func (s *SVGImage) AntiAlias() bool {
+	return s.antiAlias
+}
+
+// SetAntiAlias sets whether the image's edges are smoothed. It is on by default.
+func (s *SVGImage) SetAntiAlias(antiAlias bool) *SVGImage {
+	s.antiAlias = antiAlias
+	s.layout()
+	return s
+}
+
+// SetTransform sets a transform which is applied about the top left corner of the image,
+// after it has been scaled.
+func (s *SVGImage) SetTransform(t Transform) *SVGImage {
+	s.setTransform(t)
+	s.layout()
+	return s
+}
+
+// viewed returns a copy of the image with a view transform applied after its own,
+// and its opacity scaled.
+func (s *SVGImage) viewed(view Transform, opacity float64) Drawable {
+	v := *s
+	v.transformable = s.withView(view, s.pos)
+	v.paths = nil
+	v.layout()
+	for _, p := range v.paths {
+		p.style = p.style.faded(opacity)
+	}
+	return &v
+}
+
+// layout positions and scales the image's shapes, ready for drawing.
+func (s *SVGImage) layout() {
+	m := s.matrix(s.pos).Mul(NewTransform(s.scale.X, 0, 0, s.scale.Y, s.pos.X, s.pos.Y))
+	s.paths = s.paths[:0]
+	for _, shape := range s.shapes {
+		path := shape.path.transform(m)
+		if shape.fill != nil {
+			style := *shape.fill
+			style.AntiAlias = s.antiAlias
+			s.paths = append(s.paths, path.copy().SetStyle(style))
+		}
+		if shape.stroke != nil {
+			style := *shape.stroke
+			style.Thickness *= m.scale()
+			style.AntiAlias = s.antiAlias
+			s.paths = append(s.paths, path.copy().SetStyle(style))
+		}
+	}
+}
+
+// copy returns a copy of the path which can be changed separately.
+func (p *Path) copy() *Path {
+	c := *p
+	c.ops = append([]pathOp(nil), p.ops...)
+	return &c
+}
+
+// transform returns a copy of the path with every point transformed. The copy has no
+// transform of its own.
+func (p *Path) transform(m Transform) *Path {
+	c := p.copy()
+	c.transformable = transformable{}
+	for i := range c.ops {
+		for j := range c.ops[i].points {
+			c.ops[i].points[j] = m.Apply(c.ops[i].points[j])
+		}
+	}
+	c.start, c.current = m.Apply(c.start), m.Apply(c.current)
+	return c
+}
+
+// svgState is the inherited styling of an element in an SVG document.
+type svgState struct {
+	transform     Transform
+	colour        color.Color // value of currentColor
+	fill          color.Color // nil for none
+	stroke        color.Color // nil for none
+	strokeWidth   float64
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64
+	fillRule      FillRule
+	cap           LineCap
+	join          LineJoin
+}
+
+// parseSVG parses an SVG document into a vector image.
+func parseSVG(r io.Reader) (*SVGImage, error) {
+	img := &SVGImage{scale: Vec{1, 1}, antiAlias: true}
+	states := []svgState{{
+		transform:     IdentityTransform,
+		colour:        Black,
+		fill:          Black,
+		strokeWidth:   1,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+	}}
+	foundRoot := false
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			attrs := svgAttrs(t)
+			state := states[len(states)-1]
+			if !foundRoot {
+				if t.Name.Local != "svg" {
+					return nil, fmt.Errorf("expected svg root element, got %s", t.Name.Local)
+				}
+				foundRoot = true
+				viewBox, err := img.setSize(attrs)
+				if err != nil {
+					return nil, err
+				}
+				state.transform = viewBox
+			}
+
+			// Styles are only read from elements which are drawn, so that elements which
+			// are ignored can't stop the document from being parsed
+			switch t.Name.Local {
+			case "svg", "g", "a":
+				if err := state.apply(attrs); err != nil {
+					return nil, fmt.Errorf("invalid %s element: %w", t.Name.Local, err)
+				}
+				states = append(states, state)
+				continue
+			case "rect", "circle", "ellipse", "line", "polyline", "polygon", "path":
+				if err := state.apply(attrs); err != nil {
+					return nil, fmt.Errorf("invalid %s element: %w", t.Name.Local, err)
+				}
+				path, err := svgElementPath(t.Name.Local, attrs)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s element: %w", t.Name.Local, err)
+				}
+				img.addShape(path, state, t.Name.Local != "line")
+			}
+			if err := decoder.Skip(); err != nil {
+				return nil, err
+			}
+
+		case xml.EndElement:
+			states = states[:len(states)-1]
+		}
+	}
+	if !foundRoot {
+		return nil, errors.New("no svg element found")
+	}
+
+	img.layout()
+	return img, nil
+}
+
+// setSize sets the image's size from the root element, returning the transform which
+// maps its view box onto that size.
+func (s *SVGImage) setSize(attrs map[string]string) (Transform, error) {
+	var viewBox []float64
+	if v, ok := attrs["viewBox"]; ok {
+		var err error
+		if viewBox, err = svgNumbers(v); err != nil || len(viewBox) != 4 {
+			return Transform{}, fmt.Errorf("invalid view box %q", v)
+		}
+	}
+
+	// Sizes default to the view box, or failing that the SVG default of 300x150
+	s.width, s.height = 300, 150
+	if viewBox != nil {
+		s.width, s.height = viewBox[2], viewBox[3]
+	}
+	if w, err := svgLength(attrs["width"]); err == nil {
+		s.width = w
+	}
+	if h, err := svgLength(attrs["height"]); err == nil {
+		s.height = h
+	}
+	if s.width <= 0 || s.height <= 0 {
+		return Transform{}, fmt.Errorf("invalid size %sx%s", svgNum(s.width), svgNum(s.height))
+	}
+
+	if viewBox == nil || viewBox[2] <= 0 || viewBox[3] <= 0 {
+		return IdentityTransform, nil
+	}
+	sx, sy := s.width/viewBox[2], s.height/viewBox[3]
+	return NewTransform(sx, 0, 0, sy, -viewBox[0]*sx, -viewBox[1]*sy), nil
+}
+
+// addShape adds a shape to the image with the given styling. Shapes which can't be
+// filled are only stroked.
+func (s *SVGImage) addShape(path *Path, state svgState, fillable bool) {
+	shape := svgShape{
+		path: path.transform(state.transform).SetFillRule(state.fillRule).SetCap(state.cap).SetJoin(state.join),
+	}
+	// Fills and strokes which are fully transparent are skipped
+	if opacity := state.opacity * state.fillOpacity; state.fill != nil && fillable && opacity > 0 {
+		shape.fill = &Style{
+			Colour:  state.fill,
+			Blend:   AlphaBlend,
+			Opacity: new(opacity),
+		}
+	}
+	if opacity := state.opacity * state.strokeOpacity; state.stroke != nil && state.strokeWidth > 0 && opacity > 0 {
+		shape.stroke = &Style{
+			Colour:    state.stroke,
+			Thickness: state.strokeWidth * state.transform.scale(),
+			Blend:     AlphaBlend,
+			Opacity:   new(opacity),
+		}
+	}
+	if shape.fill != nil || shape.stroke != nil {
+		s.shapes = append(s.shapes, shape)
+	}
+}
+
+// svgAttrs returns the attributes of an element, including properties set by its style
+// attribute, which take priority.
+func svgAttrs(element xml.StartElement) map[string]string {
+	attrs := make(map[string]string, len(element.Attr))
+	for _, attr := range element.Attr {
+		attrs[attr.Name.Local] = strings.TrimSpace(attr.Value)
+	}
+	for _, declaration := range strings.Split(attrs["style"], ";") {
+		if name, value, ok := strings.Cut(declaration, ":"); ok {
+			attrs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	for name, value := range attrs {
+		if value == "inherit" {
+			delete(attrs, name) // already inherited from the parent's state
+		}
+	}
+	return attrs
+}
+
+// apply updates the state with an element's presentation attributes.
+func (st *svgState) apply(attrs map[string]string) error {
+	var err error
+	if v, ok := attrs["transform"]; ok {
+		m, err := svgTransform(v)
+		if err != nil {
+			return err
+		}
+		st.transform = st.transform.Mul(m)
+	}
+	if v, ok := attrs["color"]; ok {
+		if st.colour, err = svgColour(v, st.colour); err != nil {
+			return err
+		}
+	}
+	if v, ok := attrs["fill"]; ok {
+		if st.fill, err = svgColour(v, st.colour); err != nil {
+			return err
+		}
+	}
+	if v, ok := attrs["stroke"]; ok {
+		if st.stroke, err = svgColour(v, st.colour); err != nil {
+			return err
+		}
+	}
+	if v, ok := attrs["stroke-width"]; ok {
+		if st.strokeWidth, err = svgLength(v); err != nil {
+			return err
+		}
+	}
+	for name, opacity := range map[string]*float64{
+		"fill-opacity":   &st.fillOpacity,
+		"stroke-opacity": &st.strokeOpacity,
+	} {
+		if v, ok := attrs[name]; ok {
+			if *opacity, err = strconv.ParseFloat(v, 64); err != nil {
+				return fmt.Errorf("invalid %s %q", name, v)
+			}
+		}
+	}
+	if v, ok := attrs["opacity"]; ok {
+		// Group opacity is approximated by fading each shape in the group
+		opacity, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid opacity %q", v)
+		}
+		st.opacity *= opacity
+	}
+	if v, ok := attrs["fill-rule"]; ok {
+		st.fillRule = FillNonZero
+		if v == "evenodd" {
+			st.fillRule = FillEvenOdd
+		}
+	}
+	if v, ok := attrs["stroke-linecap"]; ok {
+		st.cap = map[string]LineCap{"round": CapRound, "square": CapSquare}[v]
+	}
+	if v, ok := attrs["stroke-linejoin"]; ok {
+		st.join = map[string]LineJoin{"round": JoinRound, "bevel": JoinBevel}[v]
+	}
+	return nil
+}
+
+// svgElementPath returns the outline of a basic SVG shape element as a path.
+func svgElementPath(name string, attrs map[string]string) (*Path, error) {
+	var err error
+	length := func(attr string) float64 {
+		v, ok := attrs[attr]
+		if !ok || err != nil {
+			return 0
+		}
+		var l float64
+		l, err = svgLength(v)
+		return l
+	}
+
+	p := NewPath()
+	switch name {
+	case "rect":
+		x, y, w, h := length("x"), length("y"), length("width"), length("height")
+		rx, ry := length("rx"), length("ry")
+		if _, ok := attrs["rx"]; !ok {
+			rx = ry
+		}
+		if _, ok := attrs["ry"]; !ok {
+			ry = rx
+		}
+		rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+		if w <= 0 || h <= 0 {
+			break
+		}
+		p.MoveTo(Vec{x + rx, y}).
+			LineTo(Vec{x + w - rx, y}).
+			ArcTo(rx, ry, 0, false, true, Vec{x + w, y + ry}).
+			LineTo(Vec{x + w, y + h - ry}).
+			ArcTo(rx, ry, 0, false, true, Vec{x + w - rx, y + h}).
+			LineTo(Vec{x + rx, y + h}).
+			ArcTo(rx, ry, 0, false, true, Vec{x, y + h - ry}).
+			LineTo(Vec{x, y + ry}).
+			ArcTo(rx, ry, 0, false, true, Vec{x + rx, y}).
+			Close()
+	case "circle", "ellipse":
+		cx, cy := length("cx"), length("cy")
+		rx, ry := length("rx"), length("ry")
+		if name == "circle" {
+			rx = length("r")
+			ry = rx
+		}
+		if rx <= 0 || ry <= 0 {
+			break
+		}
+		p.MoveTo(Vec{cx + rx, cy}).
+			ArcTo(rx, ry, 0, false, true, Vec{cx - rx, cy}).
+			ArcTo(rx, ry, 0, false, true, Vec{cx + rx, cy}).
+			Close()
+	case "line":
+		p.MoveTo(Vec{length("x1"), length("y1")}).LineTo(Vec{length("x2"), length("y2")})
+	case "polyline", "polygon":
+		points, err := svgNumbers(attrs["points"])
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+1 < len(points); i += 2 {
+			if i == 0 {
+				p.MoveTo(Vec{points[i], points[i+1]})
+			} else {
+				p.LineTo(Vec{points[i], points[i+1]})
+			}
+		}
+		if name == "polygon" {
+			p.Close()
+		}
+	case "path":
+		return ParsePathData(attrs["d"])
+	}
+	return p, err
+}
+
+// ParsePathData parses SVG path data, as found in the d attribute of a path element,
+// into a path.
+func ParsePathData(d string) (*Path, error) {
+	p := NewPath()
+	sc := &svgScanner{s: d}
+
+	var cmd, prev byte // current and previous commands, in upper case
+	var relative bool
+	var ctrl Vec // last control point of the previous curve
+	for !sc.done() {
+		if c := sc.s[sc.i]; strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+			cmd, relative = c&^0x20, c >= 'a'
+			sc.i++
+		} else if cmd == 0 || cmd == 'Z' {
+			return nil, fmt.Errorf("expected path command at %q", sc.rest())
+		}
+
+		var origin Vec
+		if relative {
+			origin = p.current
+		}
+		args, err := sc.pathArgs(cmd)
+		if err != nil {
+			return nil, err
+		}
+		pt := func(i int) Vec {
+			return Vec{origin.X + args[i], origin.Y + args[i+1]}
+		}
+		// reflect returns the reflection of the previous control point about the
+		// current point, if the previous command was one of the given curves
+		reflect := func(curves string) Vec {
+			if strings.IndexByte(curves, prev) < 0 {
+				return p.current
+			}
+			return Vec{2*p.current.X - ctrl.X, 2*p.current.Y - ctrl.Y}
+		}
+
+		switch cmd {
+		case 'M':
+			p.MoveTo(pt(0))
+			cmd = 'L' // further coordinates are implicit line commands
+		case 'L':
+			p.LineTo(pt(0))
+		case 'H':
+			p.LineTo(Vec{origin.X + args[0], p.current.Y})
+		case 'V':
+			p.LineTo(Vec{p.current.X, origin.Y + args[0]})
+		case 'C':
+			ctrl = pt(2)
+			p.CubicTo(pt(0), ctrl, pt(4))
+		case 'S':
+			c1 := reflect("CS")
+			ctrl = pt(0)
+			p.CubicTo(c1, ctrl, pt(2))
+		case 'Q':
+			ctrl = pt(0)
+			p.QuadTo(ctrl, pt(2))
+		case 'T':
+			ctrl = reflect("QT")
+			p.QuadTo(ctrl, pt(0))
+		case 'A':
+			p.ArcTo(args[0], args[1], args[2]*math.Pi/180, args[3] != 0, args[4] != 0, pt(5))
+		case 'Z':
+			p.Close()
+		}
+		prev = cmd
+	}
+	return p, nil
+}
+
+// svgScanner reads numbers from SVG attributes, which can be separated by whitespace
+// and commas, or nothing at all where it isn't ambiguous.
+type svgScanner struct {
+	s string
+	i int
+}
+
+// skip skips over separators.
+func (sc *svgScanner) skip() {
+	for sc.i < len(sc.s) && strings.IndexByte(" \t\r\n,", sc.s[sc.i]) >= 0 {
+		sc.i++
+	}
+}
+
+// done returns whether everything has been read, after skipping separators.
+func (sc *svgScanner) done() bool {
+	sc.skip()
+	return sc.i >= len(sc.s)
+}
+
+// rest returns the unread part of the string, for error messages.
+func (sc *svgScanner) rest() string {
+	if rest := sc.s[sc.i:]; len(rest) <= 10 {
+		return rest
+	}
+	return sc.s[sc.i:sc.i+10] + "..."
+}
+
+// number reads a number.
+func (sc *svgScanner) number() (float64, error) {
+	sc.skip()
+	start := sc.i
+	isDigit := func(i int) bool {
+		return i < len(sc.s) && sc.s[i] >= '0' && sc.s[i] <= '9'
+	}
+
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	digits := isDigit(sc.i)
+	for isDigit(sc.i) {
+		sc.i++
+	}
+	if sc.i < len(sc.s) && sc.s[sc.i] == '.' {
+		sc.i++
+		digits = digits || isDigit(sc.i)
+		for isDigit(sc.i) {
+			sc.i++
+		}
+	}
+	if !digits {
+		sc.i = start
+		return 0, fmt.Errorf("expected number at %q", sc.rest())
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		exp := sc.i + 1
+		if exp < len(sc.s) && (sc.s[exp] == '+' || sc.s[exp] == '-') {
+			exp++
+		}
+		if isDigit(exp) {
+			sc.i = exp
+			for isDigit(sc.i) {
+				sc.i++
+			}
+		}
+	}
+	return strconv.ParseFloat(sc.s[start:sc.i], 64)
+}
+
+// flag reads an arc flag, which is a single 0 or 1.
+func (sc *svgScanner) flag() (float64, error) {
+	sc.skip()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return float64(sc.s[sc.i-1] - '0'), nil
+	}
+	return 0, fmt.Errorf("expected flag at %q", sc.rest())
+}
+
+// pathArgs reads the arguments of one path command.
+func (sc *svgScanner) pathArgs(cmd byte) ([]float64, error) {
+	count := map[byte]int{'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'T': 2, 'A': 7}[cmd]
+	args := make([]float64, count)
+	for i := range args {
+		var err error
+		if cmd == 'A' && (i == 3 || i == 4) {
+			args[i], err = sc.flag()
+		} else {
+			args[i], err = sc.number()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// svgNumbers parses a list of numbers.
+func svgNumbers(s string) ([]float64, error) {
+	var numbers []float64
+	sc := &svgScanner{s: s}
+	for !sc.done() {
+		n, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// svgLength parses a length in pixels. Percentages and other units aren't supported.
+func svgLength(s string) (float64, error) {
+	l, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "px"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported length %q", s)
+	}
+	return l, nil
+}
+
+// svgTransform parses a transform attribute.
+func svgTransform(s string) (Transform, error) {
+	m := IdentityTransform
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		name, after, ok := strings.Cut(rest, "(")
+		argList, next, ok2 := strings.Cut(after, ")")
+		if !ok || !ok2 {
+			return Transform{}, fmt.Errorf("invalid transform %q", s)
+		}
+		rest = strings.TrimLeft(next, " \t\r\n,")
+		args, err := svgNumbers(argList)
+		if err != nil {
+			return Transform{}, fmt.Errorf("invalid transform %q: %w", s, err)
+		}
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+
+		var t Transform
+		switch name = strings.TrimSpace(name); name {
+		case "matrix":
+			if len(args) != 6 {
+				return Transform{}, fmt.Errorf("invalid transform %q", s)
+			}
+			t = NewTransform(args[0], args[1], args[2], args[3], args[4], args[5])
+		case "translate":
+			t = Translate(Vec{arg(0, 0), arg(1, 0)})
+		case "scale":
+			t = Scale(arg(0, 1), arg(1, arg(0, 1)))
+		case "rotate":
+			t = Rotate(arg(0, 0) * math.Pi / 180).about(Vec{arg(1, 0), arg(2, 0)})
+		case "skewX":
+			t = Skew(arg(0, 0)*math.Pi/180, 0)
+		case "skewY":
+			t = Skew(0, arg(0, 0)*math.Pi/180)
+		default:
+			return Transform{}, fmt.Errorf("unsupported transform %q", name)
+		}
+		m = m.Mul(t)
+	}
+	return m, nil
+}
+
+// svgColour parses a paint or colour value, returning nil for none. Paint servers such
+// as gradients aren't supported, so they're replaced with their fallback colour.
+func svgColour(s string, current color.Color) (color.Color, error) {
+	if strings.HasPrefix(s, "url(") {
+		_, fallback, _ := strings.Cut(s, ")")
+		if s = strings.TrimSpace(fallback); s == "" {
+			return nil, nil
+		}
+	}
+
+	switch lower := strings.ToLower(s); {
+	case lower == "none" || lower == "transparent":
+		return nil, nil
+	case lower == "currentcolor":
+		return current, nil
+	case strings.HasPrefix(lower, "#"):
+		hex := lower[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return nil, fmt.Errorf("invalid colour %q", s)
+		}
+		return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	case strings.HasPrefix(lower, "rgb(") && strings.HasSuffix(lower, ")"):
+		parts := strings.Split(lower[4:len(lower)-1], ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid colour %q", s)
+		}
+		var rgb [3]uint8
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			percent := strings.HasSuffix(part, "%")
+			v, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid colour %q", s)
+			}
+			if percent {
+				v = v * 255 / 100
+			}
+			rgb[i] = uint8(Clamp(math.Round(v), 0, 255))
+		}
+		return RGB(rgb[0], rgb[1], rgb[2]), nil
+	default:
+		c, ok := svgColourNames[strings.ReplaceAll(lower, "gray", "grey")]
+		if !ok {
+			return nil, fmt.Errorf("unknown colour %q", s)
+		}
+		return c, nil
+	}
+}
+
+// svgColourNames are the named colours which SVG documents can use.
+var svgColourNames = map[string]color.RGBA{
+	"black":                Black,
+	"white":                White,
+	"red":                  Red,
+	"lime":                 Lime,
+	"blue":                 Blue,
+	"yellow":               Yellow,
+	"cyan":                 Cyan,
+	"magenta":              Magenta,
+	"fuchsia":              Magenta,
+	"silver":               Silver,
+	"grey":                 Grey,
+	"maroon":               Maroon,
+	"olive":                Olive,
+	"green":                Green,
+	"purple":               Purple,
+	"teal":                 Teal,
+	"navy":                 Navy,
+	"darkred":              DarkRed,
+	"brown":                Brown,
+	"firebrick":            Firebrick,
+	"crimson":              Crimson,
+	"tomato":               Tomato,
+	"coral":                Coral,
+	"indianred":            IndianRed,
+	"lightcoral":           LightCoral,
+	"darksalmon":           DarkSalmon,
+	"salmon":               Salmon,
+	"lightsalmon":          LightSalmon,
+	"orangered":            OrangeRed,
+	"darkorange":           DarkOrange,
+	"orange":               Orange,
+	"gold":                 Gold,
+	"darkgoldenrod":        DarkGoldenRod,
+	"goldenrod":            GoldenRod,
+	"palegoldenrod":        PaleGoldenRod,
+	"darkkhaki":            DarkKhaki,
+	"khaki":                Khaki,
+	"yellowgreen":          YellowGreen,
+	"darkolivegreen":       DarkOliveGreen,
+	"olivedrab":            OliveDrab,
+	"lawngreen":            LawnGreen,
+	"chartreuse":           Chartreuse,
+	"greenyellow":          GreenYellow,
+	"darkgreen":            DarkGreen,
+	"forestgreen":          ForestGreen,
+	"limegreen":            LimeGreen,
+	"lightgreen":           LightGreen,
+	"palegreen":            PaleGreen,
+	"darkseagreen":         DarkSeaGreen,
+	"mediumspringgreen":    MediumSpringGreen,
+	"springgreen":          SpringGreen,
+	"seagreen":             SeaGreen,
+	"mediumaquamarine":     MediumAquaMarine,
+	"mediumseagreen":       MediumSeaGreen,
+	"lightseagreen":        LightSeaGreen,
+	"darkslategrey":        DarkSlateGrey,
+	"darkcyan":             DarkCyan,
+	"aqua":                 Aqua,
+	"lightcyan":            LightCyan,
+	"darkturquoise":        DarkTurquoise,
+	"turquoise":            Turquoise,
+	"mediumturquoise":      MediumTurquoise,
+	"paleturquoise":        PaleTurquoise,
+	"aquamarine":           AquaMarine,
+	"powderblue":           PowderBlue,
+	"cadetblue":            CadetBlue,
+	"steelblue":            SteelBlue,
+	"cornflowerblue":       CornBlowerBlue,
+	"deepskyblue":          DeepSkyBlue,
+	"dodgerblue":           DodgerBlue,
+	"lightblue":            LightBlue,
+	"skyblue":              SkyBlue,
+	"lightskyblue":         LighSkyBlue,
+	"midnightblue":         MidnightBlue,
+	"darkblue":             DarkBlue,
+	"mediumblue":           MediumBlue,
+	"royalblue":            RoyalBlue,
+	"blueviolet":           BlueViolet,
+	"indigo":               Indigo,
+	"darkslateblue":        DarkSlateBlue,
+	"slateblue":            SlateBlue,
+	"mediumslateblue":      MediumSlateBlue,
+	"mediumpurple":         MediumPurple,
+	"darkmagenta":          DarkMagenta,
+	"darkviolet":           DarkViolet,
+	"darkorchid":           DarkOrchid,
+	"mediumorchid":         MediumOrchid,
+	"thistle":              Thistle,
+	"plum":                 Plum,
+	"violet":               Violet,
+	"orchid":               Orchid,
+	"mediumvioletred":      MediumVioletRed,
+	"palevioletred":        PaleVioletRed,
+	"deeppink":             DeepPink,
+	"hotpink":              HotPink,
+	"lightpink":            LightPink,
+	"pink":                 Pink,
+	"antiquewhite":         AntiqueWhite,
+	"beige":                Beige,
+	"bisque":               Bisque,
+	"blanchedalmond":       BlanchedAlmond,
+	"wheat":                Wheat,
+	"cornsilk":             CornSilk,
+	"lemonchiffon":         LemonChiffon,
+	"lightgoldenrodyellow": LightGoldenRodYellow,
+	"lightyellow":          LightYellow,
+	"saddlebrown":          SaddleBrown,
+	"sienna":               Sienna,
+	"chocolate":            Chocolate,
+	"peru":                 Peru,
+	"sandybrown":           SandyBrown,
+	"burlywood":            BurlyWood,
+	"tan":                  Tan,
+	"rosybrown":            RosyBrown,
+	"moccasin":             Moccasin,
+	"navajowhite":          NavajoWhite,
+	"peachpuff":            PeachPuff,
+	"mistyrose":            MistyRose,
+	"lavenderblush":        LavenderBlush,
+	"linen":                Linen,
+	"oldlace":              OldLace,
+	"papayawhip":           PapayaWhip,
+	"seashell":             SeaShell,
+	"mintcream":            MintCream,
+	"slategrey":            SlateGrey,
+	"lightslategrey":       LightSlateGrey,
+	"lightsteelblue":       LightSteelBlue,
+	"lavender":             Lavender,
+	"floralwhite":          FloralWhite,
+	"aliceblue":            AliceBlue,
+	"ghostwhite":           GhostWhite,
+	"honeydew":             Honeydew,
+	"ivory":                Ivory,
+	"azure":                Azure,
+	"snow":                 Snow,
+	"dimgrey":              DimGrey,
+	"darkgrey":             DarkGrey,
+	"lightgrey":            LightGrey,
+	"gainsboro":            Gainsboro,
+	"whitesmoke":           WhiteSmoke,
+}
@@ -0,0 +1,189 @@
+package gogl
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestParsePathData(t *testing.T) {
+	for _, tc := range []struct {
+		d    string
+		want []pathOp
+	}{
+		{
+			d: "M10 20 L30,40 h5 v-5 Z",
+			want: []pathOp{
+				{verb: moveVerb, points: [3]Vec{{10, 20}}},
+				{verb: lineVerb, points: [3]Vec{{30, 40}}},
+				{verb: lineVerb, points: [3]Vec{{35, 40}}},
+				{verb: lineVerb, points: [3]Vec{{35, 35}}},
+				{verb: closeVerb, points: [3]Vec{{10, 20}}},
+			},
+		},
+		{
+			// Relative commands, implicit line commands after a move, and numbers
+			// without separators
+			d: "m1-2 3.5.5l-1e1,0",
+			want: []pathOp{
+				{verb: moveVerb, points: [3]Vec{{1, -2}}},
+				{verb: lineVerb, points: [3]Vec{{4.5, -1.5}}},
+				{verb: lineVerb, points: [3]Vec{{-5.5, -1.5}}},
+			},
+		},
+		{
+			// Smooth curves reflect the previous control point
+			d: "M0 0 C0 10 10 10 10 0 S20 -10 20 0 Q25 5 30 0 T40 0",
+			want: []pathOp{
+				{verb: moveVerb, points: [3]Vec{{0, 0}}},
+				{verb: cubicVerb, points: [3]Vec{{0, 10}, {10, 10}, {10, 0}}},
+				{verb: cubicVerb, points: [3]Vec{{10, -10}, {20, -10}, {20, 0}}},
+				{verb: quadVerb, points: [3]Vec{{25, 5}, {30, 0}}},
+				{verb: quadVerb, points: [3]Vec{{35, -5}, {40, 0}}},
+			},
+		},
+	} {
+		p, err := ParsePathData(tc.d)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", tc.d, err)
+			continue
+		}
+		if len(p.ops) != len(tc.want) {
+			t.Errorf("Parsing %q: expected %d commands, got %d", tc.d, len(tc.want), len(p.ops))
+			continue
+		}
+		for i, op := range p.ops {
+			if op != tc.want[i] {
+				t.Errorf("Parsing %q: expected command %d to be %v, got %v", tc.d, i, tc.want[i], op)
+			}
+		}
+	}
+}
+
+func TestParsePathDataArcFlags(t *testing.T) {
+	// Flags don't need separators
+	p, err := ParsePathData("M0 0a10 10 0 0110 10")
+	if err != nil {
+		t.Fatalf("Failed to parse arc: %v", err)
+	}
+	if end := p.ops[len(p.ops)-1].end(); end != (Vec{10, 10}) {
+		t.Errorf("Expected arc to end at {10, 10}, got %v", end)
+	}
+}
+
+func TestParsePathDataErrors(t *testing.T) {
+	for _, d := range []string{"10 20", "M10", "M0 0 L1 x", "M0 0 A1 1 0 2 0 5 5", "M0 0 Z 1 2"} {
+		if _, err := ParsePathData(d); err == nil {
+			t.Errorf("Expected error parsing %q", d)
+		}
+	}
+}
+
+func TestParseSVG(t *testing.T) {
+	img, err := ParseSVG(strings.NewReader(`
+		<svg xmlns="http://www.w3.org/2000/svg" width="20px" height="10" viewBox="0 0 40 20">
+			<title fill="unsupported">Ignored elements aren't styled</title>
+			<text transform="spin(3)">hi</text>
+			<g fill="red" transform="translate(10 0)">
+				<rect width="10" height="10"/>
+				<circle cx="5" cy="5" r="2" style="fill: none; stroke: #00f; stroke-width: 2"/>
+			</g>
+			<line x1="0" y1="0" x2="40" y2="20" stroke="gray"/>
+		</svg>`))
+	if err != nil {
+		t.Fatalf("Failed to parse SVG: %v", err)
+	}
+
+	if img.Width() != 20 || img.Height() != 10 {
+		t.Errorf("Expected size 20x10, got %vx%v", img.Width(), img.Height())
+	}
+	if len(img.shapes) != 3 {
+		t.Fatalf("Expected 3 shapes, got %d", len(img.shapes))
+	}
+
+	// The view box halves the size of everything
+	rect := img.shapes[0]
+	if rect.fill == nil || rect.fill.Colour != Red || rect.stroke != nil {
+		t.Errorf("Expected rect to be filled red without a stroke")
+	}
+	if minV, maxV := pointExtent(rect.path.flatPoints()); minV != (Vec{5, 0}) || maxV != (Vec{10, 5}) {
+		t.Errorf("Expected rect to span {5, 0} to {10, 5}, got %v to %v", minV, maxV)
+	}
+
+	circle := img.shapes[1]
+	if circle.fill != nil || circle.stroke == nil || circle.stroke.Colour != Blue {
+		t.Errorf("Expected circle to be stroked blue without a fill")
+	} else if circle.stroke.Thickness != 1 {
+		t.Errorf("Expected circle stroke to be scaled to 1, got %v", circle.stroke.Thickness)
+	}
+
+	line := img.shapes[2]
+	if line.fill != nil || line.stroke == nil || line.stroke.Colour != Grey {
+		t.Errorf("Expected line to be stroked grey without a fill")
+	}
+}
+
+func TestParseSVGTransparent(t *testing.T) {
+	img, err := ParseSVG(strings.NewReader(`
+		<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+			<rect width="10" height="10" fill="red" fill-opacity="0"/>
+			<rect width="10" height="10" fill="red" stroke="blue" stroke-width="2" stroke-opacity="0" opacity="0.5"/>
+			<g opacity="0">
+				<rect width="10" height="10" fill="red" stroke="blue"/>
+			</g>
+		</svg>`))
+	if err != nil {
+		t.Fatalf("Failed to parse SVG: %v", err)
+	}
+
+	// Only the translucent fill of the second rectangle is drawn
+	if len(img.shapes) != 1 {
+		t.Fatalf("Expected 1 shape, got %d", len(img.shapes))
+	}
+	if shape := img.shapes[0]; shape.fill == nil || shape.stroke != nil {
+		t.Errorf("Expected the transparent stroke to be skipped")
+	}
+	f := NewFrameBuffer(10, 10)
+	img.Draw(f)
+	if _, _, _, a := RGBA8(f.GetPixel(5, 5)); a != 128 {
+		t.Errorf("Expected the fill to be drawn at half opacity, got alpha %d", a)
+	}
+}
+
+func TestParseSVGErrors(t *testing.T) {
+	for _, doc := range []string{
+		`<html></html>`,
+		`<svg><rect fill="nope" width="1" height="1"/></svg>`,
+		`<svg><path d="M0 0 L"/></svg>`,
+		`<svg><g transform="spin(3)"></g></svg>`,
+		`<svg><rect`,
+		`<svg width="0" height="10"></svg>`,
+		`<svg viewBox="0 0 10 0"></svg>`,
+	} {
+		if _, err := ParseSVG(strings.NewReader(doc)); err == nil {
+			t.Errorf("Expected error parsing %s", doc)
+		}
+	}
+}
+
+func TestSVGColour(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want color.Color
+	}{
+		{"#f80", color.RGBA{255, 136, 0, 255}},
+		{"#1A2b3C", color.RGBA{26, 43, 60, 255}},
+		{"rgb(255, 0, 128)", color.RGBA{255, 0, 128, 255}},
+		{"rgb(100%,50%,0%)", color.RGBA{255, 128, 0, 255}},
+		{"CornflowerBlue", CornBlowerBlue},
+		{"darkgray", DarkGrey},
+		{"currentColor", Teal},
+		{"url(#gradient) red", Red},
+		{"url(#gradient)", nil},
+		{"none", nil},
+	} {
+		if got, err := svgColour(tc.s, Teal); err != nil || got != tc.want {
+			t.Errorf("Parsing %q: expected %v, got %v (error %v)", tc.s, tc.want, got, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package gogl
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SVGDrawable is a drawable which can be written as an SVG element.
+type SVGDrawable interface {
+	Drawable
+
+	// SVG returns the drawable as an SVG element.
+	SVG() string
+}
+
+var (
+	_ SVGDrawable = (*Rect)(nil)
+	_ SVGDrawable = (*CurvedRect)(nil)
+	_ SVGDrawable = (*Circle)(nil)
+	_ SVGDrawable = (*Ellipse)(nil)
+	_ SVGDrawable = (*Triangle)(nil)
+	_ SVGDrawable = (*Polygon)(nil)
+	_ SVGDrawable = (*Line)(nil)
+	_ SVGDrawable = (*Polyline)(nil)
+	_ SVGDrawable = (*Path)(nil)
+	_ SVGDrawable = (*Text)(nil)
+	_ SVGDrawable = (*SVGImage)(nil)
+)
+
+// WriteSVG writes drawables as an SVG document of the given size in pixels. Drawables
+// are written in order, so later ones appear on top. Drawables which can't be written
+// as SVG are left out, with a comment in their place.
+//
+// Blend functions aren't written; shapes are drawn with normal alpha blending. Gradients
+// and image patterns are written as a single colour.
+func WriteSVG(w io.Writer, width, height int, drawables []Drawable) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	for _, d := range drawables {
+		if s, ok := d.(SVGDrawable); ok {
+			fmt.Fprintf(&sb, "  %s\n", s.SVG())
+		} else {
+			fmt.Fprintf(&sb, "  <!-- %T can't be written as SVG -->\n", d)
+		}
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// SaveSVG writes drawables to an SVG file the size of the window, on top of the
+// window's background colour.
+func (w *Window) SaveSVG(path string, drawables ...Drawable) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if w.background != nil {
+		background := NewRect(float64(w.Width()), float64(w.Height()), Vec{}).
+			SetStyle(Style{Colour: w.background})
+		drawables = append([]Drawable{background}, drawables...)
+	}
+	if err := WriteSVG(file, w.Width(), w.Height(), drawables); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	return file.Close()
+}
+
+// svgNum formats a number for an SVG attribute, rounded to two decimal places.
+func svgNum(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		v = 0 // avoid writing negative zero
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// svgPoints formats a list of points for an SVG points attribute.
+func svgPoints(points []Vec) string {
+	s := make([]string, len(points))
+	for i, p := range points {
+		s[i] = svgNum(p.X) + "," + svgNum(p.Y)
+	}
+	return strings.Join(s, " ")
+}
+
+// svgPaint returns the SVG attributes which paint a shape in the style's colour and
+// opacity. The shape is filled if strokeWidth is 0, and outlined otherwise.
+func svgPaint(style Style, strokeWidth float64) string {
+	var sb strings.Builder
+	paint := "fill"
+	if strokeWidth > 0 {
+		paint = "stroke"
+		fmt.Fprintf(&sb, ` fill="none" stroke-width="%s"`, svgNum(strokeWidth))
+	}
+
+	r, g, b, a := RGBA8(svgFlatColour(style))
+	fmt.Fprintf(&sb, ` %s="#%02x%02x%02x"`, paint, r, g, b)
+	if a < math.MaxUint8 {
+		fmt.Fprintf(&sb, ` %s-opacity="%s"`, paint, strconv.FormatFloat(float64(a)/math.MaxUint8, 'f', 3, 64))
+	}
+	if opacity := style.opacity(); opacity < 1 {
+		fmt.Fprintf(&sb, ` opacity="%s"`, strconv.FormatFloat(opacity, 'f', 3, 64))
+	}
+	return sb.String()
+}
+
+// svgFlatColour returns a single colour which stands in for the style's paint.
+func svgFlatColour(style Style) color.Color {
+	switch paint := style.Paint.(type) {
+	case nil:
+		return style.Colour
+	case SolidPaint:
+		return paint.Colour
+	case LinearGradient:
+		return stopColour(paint.Stops, 0)
+	case RadialGradient:
+		return stopColour(paint.Stops, 0)
+	case ConicGradient:
+		return stopColour(paint.Stops, 0)
+	default:
+		if style.Colour != nil {
+			return style.Colour
+		}
+		return color.Transparent
+	}
+}
+
+// svgStroke returns the SVG attributes for the caps and joins of a stroke, leaving out
+// the defaults.
+func svgStroke(cap LineCap, join LineJoin) string {
+	var s string
+	if cap != CapButt {
+		s += fmt.Sprintf(` stroke-linecap="%s"`, cap)
+	}
+	if join != JoinMiter {
+		s += fmt.Sprintf(` stroke-linejoin="%s"`, join)
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package gogl
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShapeSVG(t *testing.T) {
+	translucent := Style{Colour: color.RGBA{255, 0, 0, 128}, Opacity: new(0.5)}
+	outline := Style{Colour: Blue, Thickness: 2}
+
+	for _, tc := range []struct {
+		shape SVGDrawable
+		want  string
+	}{
+		{
+			NewRect(10, 20, Vec{1, 2}).SetStyle(Style{Colour: Lime}),
+			`<rect x="1" y="2" width="10" height="20" fill="#00ff00"/>`,
+		},
+		{
+			NewRect(10, 20, Vec{1, 2}).SetStyle(outline),
+			`<rect x="2" y="3" width="8" height="18" fill="none" stroke-width="2" stroke="#0000ff"/>`,
+		},
+		{
+			NewCurvedRect(10, 20, 4, Vec{1, 2}).SetStyle(outline),
+			`<rect x="2" y="3" width="8" height="18" rx="3" fill="none" stroke-width="2" stroke="#0000ff"/>`,
+		},
+		{
+			NewCircle(10, Vec{5, 5}).SetStyle(translucent),
+			`<circle cx="5" cy="5" r="5" fill="#ff0000" fill-opacity="0.502" opacity="0.500"/>`,
+		},
+		{
+			NewCircle(10, Vec{5, 5}).SetStyle(outline),
+			`<circle cx="5" cy="5" r="4" fill="none" stroke-width="2" stroke="#0000ff"/>`,
+		},
+		{
+			NewEllipse(10, 4, Vec{5, 5}).SetStyle(Style{Colour: White}),
+			`<ellipse cx="5" cy="5" rx="5" ry="2" fill="#ffffff"/>`,
+		},
+		{
+			NewTriangle(Vec{0, 0}, Vec{1.234, 0}, Vec{0, 1}).SetStyle(Style{Colour: White}),
+			`<polygon points="0,0 1.23,0 0,1" fill="#ffffff"/>`,
+		},
+		{
+			NewPolygon([]Vec{{0, 0}, {4, 0}, {4, 4}, {0, 4}}).SetStyle(Style{Colour: White}),
+			`<polygon points="0,0 4,0 4,4 0,4" fill="#ffffff"/>`,
+		},
+		{
+			NewLine(Vec{0, 0}, Vec{5, 5}).SetStyle(Style{Colour: White}).SetCap(CapRound),
+			`<line x1="0" y1="0" x2="5" y2="5" fill="none" stroke-width="1" stroke="#ffffff" stroke-linecap="round"/>`,
+		},
+		{
+			NewPolyline([]Vec{{0, 0}, {5, 5}, {10, 0}}).SetStyle(outline).SetJoin(JoinBevel),
+			`<polyline points="0,0 5,5 10,0" fill="none" stroke-width="2" stroke="#0000ff" stroke-linejoin="bevel"/>`,
+		},
+		{
+			NewPath().MoveTo(Vec{0, 0}).QuadTo(Vec{5, -5}, Vec{10, 0}).Close().
+				SetFillRule(FillEvenOdd).SetStyle(Style{Colour: White}),
+			`<path d="M 0 0 Q 5 -5 10 0 Z" fill-rule="evenodd" fill="#ffffff"/>`,
+		},
+	} {
+		if got := tc.shape.SVG(); got != tc.want {
+			t.Errorf("Expected %s, got %s", tc.want, got)
+		}
+	}
+}
+
+func TestTextSVG(t *testing.T) {
+	text := NewText("a < b\nc", Vec{10, 20}, "fonts/luxisr.ttf").SetAlignment(AlignTopCentre)
+	svg := text.SVG()
+
+	for _, want := range []string{`font-family="Luxi Sans"`, `text-anchor="middle"`, `>a &lt; b</tspan>`, `>c</tspan>`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected text SVG to contain %s, got %s", want, svg)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	path := NewPath().
+		MoveTo(Vec{1, 1}).
+		CubicTo(Vec{2, 0}, Vec{3, 0}, Vec{4, 1}).
+		LineTo(Vec{4, 4}).
+		Close().
+		SetStyle(Style{Colour: Red})
+
+	var buf bytes.Buffer
+	drawables := []Drawable{
+		NewRect(4, 4, Vec{0, 0}).SetStyle(Style{Colour: Blue}),
+		path,
+		drawableFunc(func(*FrameBuffer) {}),
+	}
+	if err := WriteSVG(&buf, 20, 10, drawables); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<!-- gogl.drawableFunc can't be written as SVG -->") {
+		t.Errorf("Expected a comment in place of the unsupported drawable, got:\n%s", buf.String())
+	}
+
+	// The document can be imported again
+	img, err := ParseSVG(&buf)
+	if err != nil {
+		t.Fatalf("Failed to parse written SVG: %v", err)
+	}
+	if img.Width() != 20 || img.Height() != 10 {
+		t.Errorf("Expected size 20x10, got %vx%v", img.Width(), img.Height())
+	}
+	if len(img.shapes) != 2 {
+		t.Fatalf("Expected 2 shapes, got %d", len(img.shapes))
+	}
+	if got, want := img.shapes[1].path.PathData(), path.PathData(); got != want {
+		t.Errorf("Expected path data %q, got %q", want, got)
+	}
+}
+
+func TestWindowSaveSVG(t *testing.T) {
+	win, _ := newHeadlessWindow(t, 40, 30)
+	win.SetBackground(Navy)
+
+	path := filepath.Join(t.TempDir(), "scene.svg")
+	if err := win.SaveSVG(path, NewCircle(10, Vec{20, 15})); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`width="40" height="30"`,
+		`<rect x="0" y="0" width="40" height="30" fill="#000080"/>`,
+		`<circle cx="20" cy="15" r="5" fill="#ffffff"/>`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected saved SVG to contain %s, got:\n%s", want, data)
+		}
+	}
+}
+
+// drawableFunc allows a function to be used as a drawable.
+type drawableFunc func(buf *FrameBuffer)
+
+func (f drawableFunc) Draw(buf *FrameBuffer) { f(buf) }
@@ -1,10 +1,10 @@
 package gogl
 
 import (
+	"encoding/xml"
 	"fmt"
 	"image"
 	"image/color"
-	"image/png"
 	"math"
 	"os"
 	"strings"
@@ -36,11 +36,13 @@ type Text struct {
 	body               string
 	pos                Vec
 	alignment          Alignment
-	customOffset       Vec // only applies in AlignCustom mode
-	colour             color.Color
+	customOffset       Vec   // only applies in AlignCustom mode
+	style              Style // thickness is ignored
 	font               *sfnt.Font
-	dpi, size, spacing float64     // settings for generating mask
-	mask               *image.RGBA // pixel image to be drawn
+	dpi, size, spacing float64      // settings for generating mask
+	mask               *image.Alpha // coverage of each pixel to be drawn
+	glyphs             *FrameBuffer // the mask as white pixels, made when first transformed
+	transformable
 }
 
 // NewText constructs a new text object with default parameters. The default font
@@ -51,7 +53,7 @@ func NewText(body string, pos Vec, fontPath string) *Text {
 		pos:          pos,
 		alignment:    AlignTopLeft,
 		customOffset: Vec{0, 0},
-		colour:       Red,
+		style:        Style{Colour: Red},
 		dpi:          80,
 		size:         20,
 		spacing:      1.5,
@@ -72,56 +74,143 @@ func NewText(body string, pos Vec, fontPath string) *Text {
 
 // Draw draws the text onto the provided frame buffer.
 func (t *Text) Draw(buf *FrameBuffer) {
-	// Calculate the offset caused by alignment option
-	xAlignmentOffset, yAlignmentOffset := func() (int, int) {
-		switch t.alignment {
-		case AlignTopLeft:
-			return 0, 0
-		case AlignTopCentre:
-			return -t.mask.Rect.Dx() / 2, 0
-		case AlignTopRight:
-			return -t.mask.Rect.Dx(), 0
-		case AlignCentreLeft:
-			return 0, -t.mask.Rect.Dy() / 2
-		case AlignCentre:
-			return -t.mask.Rect.Dx() / 2, -t.mask.Rect.Dy() / 2
-		case AlignCentreRight:
-			return -t.mask.Rect.Dx(), -t.mask.Rect.Dy() / 2
-		case AlignBottomLeft:
-			return 0, -t.mask.Rect.Dy()
-		case AlignBottomCentre:
-			return -t.mask.Rect.Dx() / 2, -t.mask.Rect.Dy()
-		case AlignBottomRight:
-			return -t.mask.Rect.Dx(), -t.mask.Rect.Dy()
-		case AlignCustom:
-			return -t.mask.Rect.Dx()/2 + int(math.Round(t.customOffset.X)),
-				-t.mask.Rect.Dy()/2 + int(math.Round(t.customOffset.Y))
-		default:
-			panic(fmt.Errorf("unsupported text alignment: %v", t.alignment))
+	if buf.isClipped(t.Bounds()) {
+		return
+	}
+
+	// Write pixels to frame buffer. The mask only holds the coverage of each pixel, which
+	// scales the alpha of the text colour so the edges are anti-aliased.
+	b := newBrush(buf, t.style)
+	origin := t.origin()
+	if t.transformed {
+		t.drawTransformed(b, origin)
+		return
+	}
+	area := t.mask.Rect.Intersect(buf.clip.Sub(origin))
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			coverage := t.mask.AlphaAt(x, y).A
+			if coverage > 0 {
+				b.plotAlpha(origin.X+x, origin.Y+y, coverage)
+			}
 		}
-	}()
+	}
+}
 
-	// Write pixels to frame buffer
-	r, g, b, a := RGBA8(t.colour)
-	for y := t.mask.Rect.Min.Y; y < t.mask.Rect.Max.Y; y++ {
-		for x := t.mask.Rect.Min.X; x < t.mask.Rect.Max.X; x++ {
-			maskRGBA := t.mask.RGBAAt(x, y)
-			if maskRGBA.A > 0 {
-				posX := int(t.pos.X) + x + xAlignmentOffset
-				posY := int(t.pos.Y) + y + yAlignmentOffset
-
-				// OpenType reduces the RGB values of border pixels for anti-aliasing.
-				// However, we are using alpha blending, so reset the RBG values to their
-				// original, and only keep the alpha channel.
-				isBorderPixel := maskRGBA.A < a
-				if isBorderPixel {
-					buf.SetPixel(posX, posY, NewPixel(color.RGBA{r, g, b, maskRGBA.A}))
-				} else {
-					buf.SetPixel(posX, posY, NewPixel(maskRGBA))
+// drawTransformed draws the text through its transform, sampling the mask in the same way
+// as a sprite samples its image.
+func (t *Text) drawTransformed(b brush, origin image.Point) {
+	if t.mask.Rect.Empty() {
+		return
+	}
+	inv, ok := t.matrix(t.pos).Invert()
+	if !ok {
+		return
+	}
+	glyphs := t.glyphMask()
+	area := t.Bounds().Intersect(b.buf.clip)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			// Map the centre of each pixel back into the mask
+			p := inv.Apply(Vec{float64(x) + 0.5, float64(y) + 0.5})
+			u, v := p.X-float64(origin.X), p.Y-float64(origin.Y)
+			if u < 0 || v < 0 || u >= float64(t.mask.Rect.Dx()) || v >= float64(t.mask.Rect.Dy()) {
+				continue
+			}
+			if coverage := glyphs.sample(u, v, glyphs.Bounds(), FilterBilinear).A(); coverage > 0 {
+				b.plotAlpha(x, y, coverage)
+			}
+		}
+	}
+}
+
+// glyphMask returns a copy of the mask as white pixels, which can be sampled between
+// pixels. It is only made when the text is first drawn transformed, and is kept until the
+// mask is regenerated. The mask must not be empty.
+func (t *Text) glyphMask() *FrameBuffer {
+	if t.glyphs == nil {
+		t.glyphs = NewFrameBuffer(t.mask.Rect.Dx(), t.mask.Rect.Dy())
+		for y := range t.mask.Rect.Dy() {
+			for x := range t.mask.Rect.Dx() {
+				if a := t.mask.AlphaAt(x, y).A; a > 0 {
+					t.glyphs.setPixel(x, y, pack(a, 0xff, 0xff, 0xff))
 				}
 			}
 		}
 	}
+	return t.glyphs
+}
+
+// Bounds returns the pixel bounding box of the text after it has been transformed.
+func (t *Text) Bounds() image.Rectangle {
+	r := t.mask.Rect.Add(t.origin())
+	if !t.transformed {
+		return r
+	}
+	m := t.matrix(t.pos)
+	minV, maxV := pointExtent([]Vec{
+		m.Apply(Vec{float64(r.Min.X), float64(r.Min.Y)}),
+		m.Apply(Vec{float64(r.Max.X), float64(r.Min.Y)}),
+		m.Apply(Vec{float64(r.Max.X), float64(r.Max.Y)}),
+		m.Apply(Vec{float64(r.Min.X), float64(r.Max.Y)}),
+	})
+	return pixelBounds(minV.X, minV.Y, maxV.X, maxV.Y)
+}
+
+// origin returns the position of the top left corner of the mask before the text is
+// transformed.
+func (t *Text) origin() image.Point {
+	xOffset, yOffset := t.alignmentOffset()
+	return image.Pt(int(t.pos.X)+xOffset, int(t.pos.Y)+yOffset)
+}
+
+// SetTransform sets a transform which is applied about the text's position.
+func (t *Text) SetTransform(m Transform) *Text {
+	t.setTransform(m)
+	return t
+}
+
+// viewed returns a copy of the text with a view transform applied after its own, and its
+// opacity scaled.
+func (t *Text) viewed(view Transform, opacity float64) Drawable {
+	v := *t
+	v.transformable = t.withView(view, t.pos)
+	v.style = v.style.faded(opacity)
+	if v.transformed && !t.mask.Rect.Empty() {
+		// Make the sampled mask here, so that it is shared with later copies
+		v.glyphs = t.glyphMask()
+	}
+	return &v
+}
+
+// alignmentOffset calculates the offset of the text mask from the text's position,
+// caused by the alignment option.
+func (t *Text) alignmentOffset() (int, int) {
+	switch t.alignment {
+	case AlignTopLeft:
+		return 0, 0
+	case AlignTopCentre:
+		return -t.mask.Rect.Dx() / 2, 0
+	case AlignTopRight:
+		return -t.mask.Rect.Dx(), 0
+	case AlignCentreLeft:
+		return 0, -t.mask.Rect.Dy() / 2
+	case AlignCentre:
+		return -t.mask.Rect.Dx() / 2, -t.mask.Rect.Dy() / 2
+	case AlignCentreRight:
+		return -t.mask.Rect.Dx(), -t.mask.Rect.Dy() / 2
+	case AlignBottomLeft:
+		return 0, -t.mask.Rect.Dy()
+	case AlignBottomCentre:
+		return -t.mask.Rect.Dx() / 2, -t.mask.Rect.Dy()
+	case AlignBottomRight:
+		return -t.mask.Rect.Dx(), -t.mask.Rect.Dy()
+	case AlignCustom:
+		return -t.mask.Rect.Dx()/2 + int(math.Round(t.customOffset.X)),
+			-t.mask.Rect.Dy()/2 + int(math.Round(t.customOffset.Y))
+	default:
+		panic(fmt.Errorf("unsupported text alignment: %v", t.alignment))
+	}
 }
 
 // Move moves the text by a given vector.
@@ -182,14 +271,21 @@ func (t *Text) SetPos(pos Vec) *Text {
 }
 
 // Colour returns the current colour.
-func (t *Text) Colour() color.Color { return t.colour }
+func (t *Text) Colour() color.Color { return t.style.Colour }
 
 // SetColour sets the text's colour.
 func (t *Text) SetColour(c color.Color) *Text {
-	t.colour = c
-	if err := t.generateMask(); err != nil {
-		panic(err)
-	}
+	t.style.Colour = c
+	return t
+}
+
+// GetStyle returns the text's style.
+func (t *Text) GetStyle() Style { return t.style }
+
+// SetStyle sets the text's style. The colour of the style is the text colour.
+// Thickness is ignored.
+func (t *Text) SetStyle(style Style) *Text {
+	t.style = style
 	return t
 }
 
@@ -248,13 +344,9 @@ func (t *Text) SetSpacing(spacing float64) *Text {
 // It should be called any time the text settings change.
 func (t *Text) generateMask() error {
 	// Load the font face
-	face, err := opentype.NewFace(t.font, &opentype.FaceOptions{
-		Size:    t.size,
-		DPI:     t.dpi,
-		Hinting: font.HintingFull,
-	})
+	face, err := t.newFace()
 	if err != nil {
-		return fmt.Errorf("failed to create font face: %w", err)
+		return err
 	}
 	defer face.Close()
 
@@ -277,10 +369,10 @@ func (t *Text) generateMask() error {
 	maskHeight := float64(faceHeight*len(splitLines)) + (0.4 * float64(faceHeight))
 
 	// Draw the font into the mask
-	mask := image.NewRGBA(image.Rect(0, 0, maskWidth, int(maskHeight)))
+	mask := image.NewAlpha(image.Rect(0, 0, maskWidth, int(maskHeight)))
 	drawer := &font.Drawer{
 		Dst:  mask,
-		Src:  image.NewUniform(t.colour),
+		Src:  image.Opaque,
 		Face: face,
 		Dot:  fixed.Point26_6{}, // set later
 	}
@@ -304,27 +396,76 @@ func (t *Text) generateMask() error {
 	}
 
 	t.mask = mask
+	t.glyphs = nil
 
 	return nil
 }
 
-// loadFont loads an OpenType font from a .ttf file.
-func loadFont(path string) (*sfnt.Font, error) {
-	fontBytes, err := os.ReadFile(path)
+// newFace creates a font face with the text's font settings.
+func (t *Text) newFace() (font.Face, error) {
+	face, err := opentype.NewFace(t.font, &opentype.FaceOptions{
+		Size:    t.size,
+		DPI:     t.dpi,
+		Hinting: font.HintingFull,
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create font face: %w", err)
 	}
-	return opentype.Parse(fontBytes)
+	return face, nil
 }
 
-// saveImageAsPNG saves an image as a PNG file. Used for testing and development.
-func saveImageAsPNG(img image.Image, filepath string) {
-	file, err := os.Create(filepath)
+// SVG returns the text as an SVG element, with each line of text positioned where it
+// is drawn on the frame buffer. The font is referred to by its family name, so viewers
+// need to have it installed.
+func (t *Text) SVG() string {
+	face, err := t.newFace()
 	if err != nil {
 		panic(err)
 	}
-	defer file.Close()
-	if err := png.Encode(file, img); err != nil {
-		panic(err)
+	defer face.Close()
+	lineHeight := face.Metrics().Height.Ceil()
+
+	family, err := t.font.Name(nil, sfnt.NameIDFamily)
+	if err != nil {
+		family = "sans-serif"
+	}
+
+	// Line up the text with the same side of the mask as the lines are drawn on
+	xOffset, yOffset := t.alignmentOffset()
+	x := int(t.pos.X) + xOffset
+	anchor := "start"
+	switch t.alignment {
+	case AlignCentre, AlignTopCentre, AlignBottomCentre, AlignCustom:
+		x += t.mask.Rect.Dx() / 2
+		anchor = "middle"
+	case AlignTopRight, AlignCentreRight, AlignBottomRight:
+		x += t.mask.Rect.Dx()
+		anchor = "end"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<text font-family="%s" font-size="%s" text-anchor="%s"%s%s>`,
+		xmlEscape(family), svgNum(t.size*t.dpi/72), anchor, svgPaint(t.style, 0), t.svgTransform(t.pos))
+	for i, line := range strings.Split(t.body, "\n") {
+		y := int(t.pos.Y) + yOffset + lineHeight*(i+1)
+		fmt.Fprintf(&sb, `<tspan x="%d" y="%d">%s</tspan>`, x, y, xmlEscape(line))
+	}
+	sb.WriteString("</text>")
+	return sb.String()
+}
+
+// xmlEscape escapes text for use in XML.
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// loadFont loads an OpenType font from a .ttf file.
+func loadFont(path string) (*sfnt.Font, error) {
+	fontBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
+	return opentype.Parse(fontBytes)
 }
@@ -2,8 +2,6 @@ package gogl
 
 import (
 	"image/color"
-
-	"github.com/jupiterrider/purego-sdl3/sdl"
 )
 
 // TextBox is a shape that can be typed in.
@@ -201,7 +199,7 @@ func (t *textMutator) String() string {
 }
 
 // handleEvent processes key press events.
-func (t *textMutator) handleEvent(event sdl.KeyboardEvent) {
+func (t *textMutator) handleEvent(event KeyEvent) {
 	if event.Key == KeyBackspace && event.Down {
 		t.backspace()
 	}
@@ -0,0 +1,232 @@
+package gogl
+
+import (
+	"fmt"
+	"math"
+)
+
+// Transform is a 2D affine transform, stored as a 3x3 matrix which multiplies the column
+// vector (x, y, 1). The bottom row is always (0, 0, 1).
+type Transform [3][3]float64
+
+// IdentityTransform is the transform which leaves points where they are.
+var IdentityTransform = Transform{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// NewTransform constructs a transform which maps (x, y) to (a*x + c*y + e, b*x + d*y + f),
+// in the same way as an SVG matrix.
+func NewTransform(a, b, c, d, e, f float64) Transform {
+	return Transform{{a, c, e}, {b, d, f}, {0, 0, 1}}
+}
+
+// Translate returns a transform which moves points by a vector.
+func Translate(v Vec) Transform {
+	return NewTransform(1, 0, 0, 1, v.X, v.Y)
+}
+
+// Rotate returns a transform which rotates points about the origin by theta radians,
+// clockwise on screen.
+func Rotate(theta float64) Transform {
+	sin, cos := math.Sincos(theta)
+	return NewTransform(cos, sin, -sin, cos, 0, 0)
+}
+
+// Scale returns a transform which scales points away from the origin.
+func Scale(sx, sy float64) Transform {
+	return NewTransform(sx, 0, 0, sy, 0, 0)
+}
+
+// Skew returns a transform which skews points by angles in radians along the x and y
+// axes, like SVG's skewX and skewY.
+func Skew(x, y float64) Transform {
+	return NewTransform(1, math.Tan(y), math.Tan(x), 1, 0, 0)
+}
+
+// Mul returns the transform which applies u, then t.
+func (t Transform) Mul(u Transform) Transform {
+	var m Transform
+	for i := range 3 {
+		for j := range 3 {
+			for k := range 3 {
+				m[i][j] += t[i][k] * u[k][j]
+			}
+		}
+	}
+	return m
+}
+
+// Invert returns the transform which undoes t. It returns false if t can't be undone,
+// because it squashes points onto a line or a single point.
+func (t Transform) Invert() (Transform, bool) {
+	a, c, e := t[0][0], t[0][1], t[0][2]
+	b, d, f := t[1][0], t[1][1], t[1][2]
+	det := a*d - b*c
+	if det == 0 {
+		return Transform{}, false
+	}
+	return NewTransform(d/det, -b/det, -c/det, a/det, (c*f-d*e)/det, (b*e-a*f)/det), true
+}
+
+// Apply transforms a point.
+func (t Transform) Apply(v Vec) Vec {
+	return Vec{
+		X: t[0][0]*v.X + t[0][1]*v.Y + t[0][2],
+		Y: t[1][0]*v.X + t[1][1]*v.Y + t[1][2],
+	}
+}
+
+// String returns the transform as an SVG matrix.
+func (t Transform) String() string {
+	return fmt.Sprintf("matrix(%s %s %s %s %s %s)",
+		svgNum(t[0][0]), svgNum(t[1][0]), svgNum(t[0][1]), svgNum(t[1][1]), svgNum(t[0][2]), svgNum(t[1][2]))
+}
+
+// scale returns the average factor which the transform scales lengths by.
+func (t Transform) scale() float64 {
+	return math.Sqrt(math.Abs(t[0][0]*t[1][1] - t[1][0]*t[0][1]))
+}
+
+// about returns the transform which applies t with origin at a point.
+func (t Transform) about(origin Vec) Transform {
+	return Translate(origin).Mul(t).Mul(Translate(Vec{-origin.X, -origin.Y}))
+}
+
+// transformable is embedded in shapes which can be transformed. The transform is applied
+// about the shape's position, and the zero value is the identity transform.
+type transformable struct {
+	transform   Transform
+	transformed bool
+}
+
+// Transform returns the shape's transform, which is applied about its position.
+func (t *transformable) Transform() Transform {
+	if !t.transformed {
+		return IdentityTransform
+	}
+	return t.transform
+}
+
+// setTransform sets the shape's transform.
+func (t *transformable) setTransform(m Transform) {
+	t.transform, t.transformed = m, m != IdentityTransform
+}
+
+// matrix returns the transform from the shape's coordinates to the frame buffer's, where
+// the shape is positioned at origin.
+func (t *transformable) matrix(origin Vec) Transform {
+	if !t.transformed {
+		return IdentityTransform
+	}
+	return t.transform.about(origin)
+}
+
+// untransform maps a point on the frame buffer back to the shape's coordinates. It
+// returns false if the transform can't be undone.
+func (t *transformable) untransform(p Vec, origin Vec) (Vec, bool) {
+	if !t.transformed {
+		return p, true
+	}
+	inv, ok := t.matrix(origin).Invert()
+	if !ok {
+		return Vec{}, false
+	}
+	return inv.Apply(p), true
+}
+
+// withView returns a copy of the shape's transform with a view transform applied after
+// it, where the shape is positioned at origin.
+func (t *transformable) withView(view Transform, origin Vec) transformable {
+	var out transformable
+	out.setTransform(Translate(Vec{-origin.X, -origin.Y}).Mul(view).Mul(t.matrix(origin)).Mul(Translate(origin)))
+	return out
+}
+
+// svgTransform returns the SVG attribute for the shape's transform, or nothing if it
+// isn't transformed.
+func (t *transformable) svgTransform(origin Vec) string {
+	if !t.transformed {
+		return ""
+	}
+	return fmt.Sprintf(` transform="%s"`, t.matrix(origin))
+}
+
+// transformPoints returns a copy of the points, transformed.
+func transformPoints(points []Vec, m Transform) []Vec {
+	out := make([]Vec, len(points))
+	for i, p := range points {
+		out[i] = m.Apply(p)
+	}
+	return out
+}
+
+// transformedStyle returns a copy of the style with its thickness scaled by a transform,
+// for drawing strokes after their points have been transformed.
+func transformedStyle(style Style, m Transform) Style {
+	style.Thickness *= m.scale()
+	return style
+}
+
+// kappa is how far the control points of a cubic Bézier curve are placed along the
+// tangents, relative to the radius, to approximate a quarter of a circle.
+const kappa = 0.5522847498
+
+// addRoundedRect adds a closed rectangle with rounded corners to the path.
+func (p *Path) addRoundedRect(pos Vec, w, h, radius float64) *Path {
+	radius = Clamp(radius, 0, math.Min(w, h)/2)
+	k := radius * (1 - kappa)
+	x0, y0, x1, y1 := pos.X, pos.Y, pos.X+w, pos.Y+h
+
+	p.MoveTo(Vec{x0 + radius, y0}).LineTo(Vec{x1 - radius, y0})
+	if radius > 0 {
+		p.CubicTo(Vec{x1 - k, y0}, Vec{x1, y0 + k}, Vec{x1, y0 + radius})
+	}
+	p.LineTo(Vec{x1, y1 - radius})
+	if radius > 0 {
+		p.CubicTo(Vec{x1, y1 - k}, Vec{x1 - k, y1}, Vec{x1 - radius, y1})
+	}
+	p.LineTo(Vec{x0 + radius, y1})
+	if radius > 0 {
+		p.CubicTo(Vec{x0 + k, y1}, Vec{x0, y1 - k}, Vec{x0, y1 - radius})
+	}
+	p.LineTo(Vec{x0, y0 + radius})
+	if radius > 0 {
+		p.CubicTo(Vec{x0, y0 + k}, Vec{x0 + k, y0}, Vec{x0 + radius, y0})
+	}
+	return p.Close()
+}
+
+// addEllipse adds a closed ellipse to the path.
+func (p *Path) addEllipse(centre Vec, rx, ry float64) *Path {
+	kx, ky := rx*kappa, ry*kappa
+	cx, cy := centre.X, centre.Y
+	return p.MoveTo(Vec{cx + rx, cy}).
+		CubicTo(Vec{cx + rx, cy + ky}, Vec{cx + kx, cy + ry}, Vec{cx, cy + ry}).
+		CubicTo(Vec{cx - kx, cy + ry}, Vec{cx - rx, cy + ky}, Vec{cx - rx, cy}).
+		CubicTo(Vec{cx - rx, cy - ky}, Vec{cx - kx, cy - ry}, Vec{cx, cy - ry}).
+		CubicTo(Vec{cx + kx, cy - ry}, Vec{cx + rx, cy - ky}, Vec{cx + rx, cy}).
+		Close()
+}
+
+// addPolygon adds a closed polygon to the path.
+func (p *Path) addPolygon(points []Vec) *Path {
+	for i, pt := range points {
+		if i == 0 {
+			p.MoveTo(pt)
+		} else {
+			p.LineTo(pt)
+		}
+	}
+	return p.Close()
+}
+
+// outlinePath returns a path for drawing a transformed shape, which is the shape's edge
+// when it is filled, or a ring between its edge and an inner edge when it is outlined.
+// The path is transformed, and styled to be filled.
+func outlinePath(style Style, m Transform, add func(p *Path, inset float64)) *Path {
+	p := NewPath().SetFillRule(FillEvenOdd)
+	add(p, 0)
+	if style.Thickness > 0 {
+		add(p, style.Thickness)
+	}
+	style.Thickness = 0
+	return p.transform(m).SetStyle(style)
+}
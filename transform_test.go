@@ -0,0 +1,183 @@
+package gogl
+
+import (
+	"image"
+	"math"
+	"strings"
+	"testing"
+)
+
+// vecNear returns true if two vectors are equal, allowing for rounding errors.
+func vecNear(a, b Vec) bool {
+	return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9
+}
+
+// boundsNear returns true if the bounds contain the wanted rectangle, with no more than a
+// few pixels of margin.
+func boundsNear(got, want image.Rectangle) bool {
+	return want.In(got) && got.In(want.Inset(-5))
+}
+
+func TestTransform(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		t    Transform
+		in   Vec
+		want Vec
+	}{
+		{"identity", IdentityTransform, Vec{3, 4}, Vec{3, 4}},
+		{"translate", Translate(Vec{1, -2}), Vec{3, 4}, Vec{4, 2}},
+		{"rotate", Rotate(math.Pi / 2), Vec{1, 0}, Vec{0, 1}},
+		{"scale", Scale(2, 3), Vec{3, 4}, Vec{6, 12}},
+		{"skew", Skew(math.Pi/4, 0), Vec{0, 2}, Vec{2, 2}},
+		// The right hand transform is applied first
+		{"mul", Translate(Vec{10, 0}).Mul(Scale(2, 2)), Vec{1, 1}, Vec{12, 2}},
+		{"matrix", NewTransform(1, 2, 3, 4, 5, 6), Vec{1, 1}, Vec{9, 12}},
+	} {
+		if got := tc.t.Apply(tc.in); !vecNear(got, tc.want) {
+			t.Errorf("%s: expected %v to map to %v, got %v", tc.name, tc.in, tc.want, got)
+		}
+
+		inv, ok := tc.t.Invert()
+		if !ok {
+			t.Fatalf("%s: expected transform to be invertible", tc.name)
+		}
+		if got := inv.Apply(tc.want); !vecNear(got, tc.in) {
+			t.Errorf("%s: expected inverse to map %v back to %v, got %v", tc.name, tc.want, tc.in, got)
+		}
+	}
+
+	if _, ok := Scale(0, 1).Invert(); ok {
+		t.Error("Expected a flattening transform not to be invertible")
+	}
+	if got, want := NewTransform(1, 2, 3, 4, 5, 6).String(), "matrix(1 2 3 4 5 6)"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestTransformedShapes(t *testing.T) {
+	// A square rotated about its corner by 45 degrees becomes a diamond
+	rect := NewRect(10, 10, Vec{20, 20}).SetTransform(Rotate(math.Pi / 4))
+	for _, tc := range []struct {
+		p    Vec
+		want bool
+	}{
+		{Vec{20, 25}, true},
+		{Vec{25, 21}, false},
+		{Vec{20, 33}, true},
+		{Vec{20, 35}, false},
+	} {
+		if got := rect.IsWithin(tc.p); got != tc.want {
+			t.Errorf("Expected IsWithin(%v) to be %v", tc.p, tc.want)
+		}
+	}
+	diag := 10 * math.Sqrt2
+	if want := pixelBounds(20-diag/2, 20, 20+diag/2, 20+diag); !boundsNear(rect.Bounds(), want) {
+		t.Errorf("Expected bounds close to %v, got %v", want, rect.Bounds())
+	}
+
+	// The circle is scaled about its centre
+	circle := NewCircle(10, Vec{20, 20}).SetTransform(Scale(2, 1))
+	if !circle.IsWithin(Vec{29, 20}) || circle.IsWithin(Vec{20, 29}) {
+		t.Error("Expected circle to be stretched horizontally")
+	}
+
+	// Shapes are drawn as they are transformed
+	f := NewFrameBuffer(40, 40)
+	NewEllipse(10, 10, Vec{20, 20}).SetTransform(Scale(3, 1)).Draw(f)
+	if f.GetPixel(33, 20) == 0 || f.GetPixel(20, 24) == 0 || f.GetPixel(20, 27) != 0 {
+		t.Error("Expected ellipse to be stretched horizontally")
+	}
+
+	// Strokes are scaled with their points
+	line := NewLine(Vec{0, 0}, Vec{10, 0}).SetStyle(Style{Thickness: 2}).SetTransform(Scale(2, 2))
+	if got, want := line.Bounds(), strokeBounds([]Vec{{0, 0}, {20, 0}}, Style{Thickness: 4}, 2); got != want {
+		t.Errorf("Expected line bounds %v, got %v", want, got)
+	}
+
+	// A transform which flattens the shape draws nothing
+	f = NewFrameBuffer(40, 40)
+	NewRect(10, 10, Vec{5, 5}).SetTransform(Scale(0, 1)).Draw(f)
+	NewSprite(NewFrameBuffer(4, 4), Vec{5, 5}).SetTransform(Scale(0, 1)).Draw(f)
+	if f.GetPixel(5, 5) != 0 {
+		t.Error("Expected flattened shapes not to be drawn")
+	}
+}
+
+func TestTransformedSprite(t *testing.T) {
+	img := NewFrameBuffer(2, 1)
+	img.SetPixel(0, 0, NewPixel(Red))
+	img.SetPixel(1, 0, NewPixel(Blue))
+
+	// Rotating a quarter turn about the pivot points the sprite downwards
+	s := NewSprite(img, Vec{4, 4}).SetTransform(Rotate(math.Pi / 2))
+	if want := pixelBounds(3, 4, 4, 6); s.Bounds() != want {
+		t.Errorf("Expected bounds %v, got %v", want, s.Bounds())
+	}
+	f := NewFrameBuffer(8, 8)
+	s.Draw(f)
+	if f.GetPixel(3, 4) != NewPixel(Red) || f.GetPixel(3, 5) != NewPixel(Blue) {
+		t.Errorf("Expected rotated sprite to be drawn downwards, got %v and %v", f.GetPixel(3, 4), f.GetPixel(3, 5))
+	}
+}
+
+func TestTransformedText(t *testing.T) {
+	text := NewText("l", Vec{10, 10}, "fonts/luxisr.ttf").SetColour(White)
+	plain := text.Bounds()
+	text.Draw(NewFrameBuffer(40, 40))
+	if text.glyphs != nil {
+		t.Error("Expected untransformed text not to copy its mask")
+	}
+
+	// A quarter turn about the position lays the text on its side, to the left
+	text.SetTransform(Rotate(math.Pi / 2))
+	got := text.Bounds()
+	if want := pixelBounds(float64(20-plain.Max.Y), 10, 10, float64(plain.Max.X)); !boundsNear(got, want) {
+		t.Errorf("Expected bounds close to %v, got %v", want, got)
+	}
+	f := NewFrameBuffer(40, 40)
+	text.Draw(f)
+	drawn := 0
+	for y := range 40 {
+		for x := range 40 {
+			if p := f.GetPixel(x, y); p != 0 {
+				drawn++
+				if !image.Pt(x, y).In(got) {
+					t.Errorf("Expected pixel (%d,%d) to be within %v", x, y, got)
+				}
+			}
+		}
+	}
+	if drawn == 0 {
+		t.Error("Expected transformed text to be drawn")
+	}
+	if want := `transform="matrix(0 1 -1 0 20 0)"`; !strings.Contains(text.SVG(), want) {
+		t.Errorf("Expected SVG to contain %s, got %s", want, text.SVG())
+	}
+	if text.SetText("i"); text.glyphs != nil {
+		t.Error("Expected copy of the mask to be dropped when the text changes")
+	}
+}
+
+func TestTransformedSVG(t *testing.T) {
+	rect := NewRect(10, 20, Vec{1, 2}).SetTransform(Rotate(math.Pi))
+	if want := `transform="matrix(-1 0 0 -1 2 4)"`; !strings.Contains(rect.SVG(), want) {
+		t.Errorf("Expected SVG to contain %s, got %s", want, rect.SVG())
+	}
+
+	// Paths are written with their points transformed
+	path := NewPath().MoveTo(Vec{1, 1}).LineTo(Vec{2, 1}).SetTransform(Scale(2, 2))
+	if want := `d="M 1 1 L 3 1"`; !strings.Contains(path.SVG(), want) {
+		t.Errorf("Expected SVG to contain %s, got %s", want, path.SVG())
+	}
+
+	// Imported images are transformed after they are scaled
+	img, err := ParseSVG(strings.NewReader(`<svg width="10" height="10"><rect width="10" height="10"/></svg>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img.SetScale(2).SetTransform(Rotate(math.Pi / 2))
+	if want := image.Rect(-20, 0, 0, 20); !boundsNear(img.Bounds(), want) {
+		t.Errorf("Expected bounds close to %v, got %v", want, img.Bounds())
+	}
+}
@@ -1,13 +1,11 @@
 package gogl
 
 import (
+	"cmp"
 	"fmt"
 	"image/color"
 	"os"
-	"unsafe"
-
-	"github.com/jupiterrider/purego-sdl3/img"
-	"github.com/jupiterrider/purego-sdl3/sdl"
+	"slices"
 )
 
 // WindowCfg contains adjustable configuration for a window.
@@ -20,15 +18,27 @@ type WindowCfg struct {
 	Icon *os.File
 	// Resizable can be set to true to allow the window to be resizable.
 	Resizable bool
+	// Backend is the platform layer used by the window. SDL is used if nil.
+	Backend Backend
+	// Renderer draws the queued shapes onto the frame buffer. Shapes are drawn one after
+	// another if nil.
+	Renderer Renderer
+	// SkipUnchangedFrames can be set to true to skip presenting frames in which
+	// nothing was drawn.
+	SkipUnchangedFrames bool
+	// Premultiplied can be set to true to draw onto a frame buffer with premultiplied
+	// alpha, which blends translucent pixels faster.
+	Premultiplied bool
 }
 
 // Window represents an OS Window.
 type Window struct {
 	Framebuffer *FrameBuffer
 
-	win      *sdl.Window
-	renderer *sdl.Renderer
-	texture  *sdl.Texture
+	backend    Backend
+	renderer   Renderer
+	background color.Color // colour last set by SetBackground, if any
+	layers     []*Layer    // in the order they were added
 
 	engine *engine
 	config WindowCfg
@@ -38,56 +48,35 @@ type Window struct {
 //
 // Call Destroy to deallocate the window.
 func NewWindow(cfg WindowCfg) (*Window, error) {
-	if ok := sdl.Init(sdl.InitVideo); !ok {
-		return nil, fmt.Errorf("failed to init sdl3: %s", sdl.GetError())
-	}
-
-	var resizableFlag3 sdl.WindowFlags
-	if cfg.Resizable {
-		resizableFlag3 = sdl.WindowResizable
-	}
-
-	w := sdl.CreateWindow(
-		cfg.Title,
-		int32(cfg.Width),
-		int32(cfg.Height),
-		resizableFlag3,
-	)
-	if w == nil {
-		return nil, fmt.Errorf("failed to create sdl3 window: %s", sdl.GetError())
+	backend := cfg.Backend
+	if backend == nil {
+		backend = newSDLBackend()
 	}
-	if !sdl.StartTextInput(w) {
-		return nil, fmt.Errorf("failed to start text input: %s", sdl.GetError())
+	if err := backend.Init(cfg); err != nil {
+		return nil, err
 	}
 
-	r := sdl.CreateRenderer(w, "")
-	if r == nil {
-		return nil, fmt.Errorf("failed to create sdl3 renderer: %s", sdl.GetError())
+	renderer := cfg.Renderer
+	if renderer == nil {
+		renderer = SequentialRenderer{}
 	}
 
-	t := sdl.CreateTexture(
-		r,
-		sdl.PixelFormatRGBA8888,
-		sdl.TextureAccessStreaming,
-		int32(cfg.Width),
-		int32(cfg.Height),
-	)
-	if t == nil {
-		return nil, fmt.Errorf("failed to create sdl3 texture: %s", sdl.GetError())
-	}
-
-	// (Optional) set window icon
-	if cfg.Icon != nil {
-		iconSurface := img.Load(cfg.Icon.Name())
-		sdl.SetWindowIcon(w, iconSurface)
+	framebuffer := NewFrameBuffer(cfg.Width, cfg.Height)
+	if cfg.Premultiplied {
+		framebuffer = NewPremultipliedFrameBuffer(cfg.Width, cfg.Height)
 	}
 
 	return &Window{
-		Framebuffer: NewFrameBuffer(cfg.Width, cfg.Height),
+		Framebuffer: framebuffer,
 
-		win:      w,
-		renderer: r,
-		texture:  t,
+		backend:  backend,
+		renderer: renderer,
+		layers: []*Layer{
+			newLayer(LayerBackground, 0),
+			newLayer(LayerWorld, 100),
+			newLayer(LayerUI, 200),
+			newLayer(LayerDebug, 300),
+		},
 
 		engine: newEngine(),
 		config: cfg,
@@ -96,25 +85,52 @@ func NewWindow(cfg WindowCfg) (*Window, error) {
 
 // Destroy deallocates the window's resources. Call it at the end of your application.
 func (w *Window) Destroy() {
-	sdl.DestroyTexture(w.texture)
-	sdl.DestroyRenderer(w.renderer)
-	sdl.DestroyWindow(w.win)
-	sdl.Quit()
+	w.backend.Destroy()
 }
 
-// Draw draws a shape to the window.
+// Draw draws a shape to the window, on the world layer.
 func (w *Window) Draw(s ...Drawable) {
-	w.engine.drawQueue = append(w.engine.drawQueue, s...)
+	w.DrawOn(LayerWorld, s...)
+}
+
+// DrawOn draws a shape to the window, on the named layer. It panics if the window has no
+// layer with the name.
+func (w *Window) DrawOn(layer string, s ...Drawable) {
+	l := w.Layer(layer)
+	l.queue = append(l.queue, s...)
+}
+
+// Layer returns the named layer. It panics if the window has no layer with the name.
+func (w *Window) Layer(name string) *Layer {
+	for _, l := range w.layers {
+		if l.name == name {
+			return l
+		}
+	}
+	panic(fmt.Sprintf("window has no layer named %q", name))
+}
+
+// AddLayer adds a new layer with a z-index, and returns it. It panics if the window
+// already has a layer with the name.
+func (w *Window) AddLayer(name string, z int) *Layer {
+	for _, l := range w.layers {
+		if l.name == name {
+			panic(fmt.Sprintf("window already has a layer named %q", name))
+		}
+	}
+	l := newLayer(name, z)
+	w.layers = append(w.layers, l)
+	return l
 }
 
 // RegisterKeybind sets a callback function which is executed when a key is pressed.
 // The callback can be executed always while the key is pressed, on press, or on release.
-func (w *Window) RegisterKeybind(key sdl.Keycode, mode KeybindMode, callback func()) {
+func (w *Window) RegisterKeybind(key Keycode, mode KeybindMode, callback func()) {
 	w.engine.keyTracker.registerKeybind(key, mode, callback)
 }
 
 // RegisterKeybind removes a keybind for a specific key mode combination.
-func (w *Window) UnregisterKeybind(key sdl.Keycode, mode KeybindMode) {
+func (w *Window) UnregisterKeybind(key Keycode, mode KeybindMode) {
 	w.engine.keyTracker.unregisterKeybind(key, mode)
 }
 
@@ -129,33 +145,34 @@ func (w *Window) DropKeybinds() {
 }
 
 // KeyIsPressed returns whether a given key is currently pressed.
-func (w *Window) KeyIsPressed(key sdl.Keycode) bool {
+func (w *Window) KeyIsPressed(key Keycode) bool {
 	return w.engine.keyTracker.isPressed(key)
 }
 
 // SetBackground sets the background to a uniform colour.
 func (w *Window) SetBackground(c color.Color) {
-	// Alpha must be 255 for bloom effects to work
-	r, g, b, _ := RGBA8(c)
-	w.Framebuffer.Fill(color.RGBA{r, g, b, 255})
+	w.background = c
+	w.Framebuffer.Fill(c)
 }
 
+// Update processes input events, draws the queued shapes to the frame buffer and
+// presents the frame buffer to the window.
 func (w *Window) Update() {
 	// Handle internal events
-	var event sdl.Event
-	for sdl.PollEvent(&event) {
-		switch event.Type() {
-		case sdl.EventQuit:
+	for {
+		event, ok := w.backend.PollEvent()
+		if !ok {
+			break
+		}
+		switch e := event.(type) {
+		case QuitEvent:
 			w.engine.running = false
-		case sdl.EventKeyDown, sdl.EventKeyUp:
-			e := event.Key()
+		case KeyEvent:
 			w.engine.keyTracker.handleEvent(e)
 			w.engine.textMutator.handleEvent(e)
-		case sdl.EventTextInput:
-			e := event.Text()
-			w.engine.textMutator.Append(e.Text())
-		case sdl.EventMouseWheel:
-			e := event.Wheel()
+		case TextInputEvent:
+			w.engine.textMutator.Append(e.Text)
+		case MouseWheelEvent:
 			w.engine.mouseScrollTracker.handleEvent(e)
 		}
 	}
@@ -163,24 +180,23 @@ func (w *Window) Update() {
 	// React to key presses
 	w.engine.keyTracker.update()
 
-	// Draw shapes to frame buffer
-	for _, shape := range w.engine.drawQueue {
-		shape.Draw(w.Framebuffer)
+	// Draw each layer's shapes to frame buffer, from the bottom up
+	layers := slices.Clone(w.layers)
+	slices.SortStableFunc(layers, func(a, b *Layer) int {
+		return cmp.Compare(a.z, b.z)
+	})
+	for _, l := range layers {
+		l.render(w.Framebuffer, w.renderer)
 	}
-	w.engine.drawQueue = nil
 
-	// Render to SDL window
-	pixels := w.Framebuffer.Bytes()
-	// Pitch is bytes per row: width * 4 bytes per pixel (RGBA8888)
-	if !sdl.UpdateTexture(w.texture, nil, unsafe.Pointer(&pixels[0]), int32(w.config.Width*pxLen)) {
-		fmt.Println("failed to update texture:", sdl.GetError())
-	}
-	if !sdl.RenderTexture(w.renderer, w.texture, nil, nil) {
-		fmt.Println("failed to render texture:", sdl.GetError())
-	}
-	if !sdl.RenderPresent(w.renderer) {
-		fmt.Println("failed to present render:", sdl.GetError())
+	// Render the changed regions to window
+	dirty := w.Framebuffer.DirtyRects()
+	if len(dirty) > 0 || !w.config.SkipUnchangedFrames {
+		if err := w.backend.Present(w.Framebuffer, dirty); err != nil {
+			fmt.Println(err)
+		}
 	}
+	w.Framebuffer.ClearDirty()
 }
 
 // IsRunning returns true while the window is running.
@@ -201,9 +217,57 @@ func (w *Window) GetConfig() WindowCfg {
 // MouseLocation returns the location of the mouse cursor, relative to
 // the origin of the window.
 func (w *Window) MouseLocation() Vec {
-	var x, y float32
-	_ = sdl.GetMouseState(&x, &y)
-	return Vec{X: float64(x), Y: float64(y)}
+	pos, _ := w.backend.Mouse()
+	return pos
+}
+
+// SetScene sets the root node of a scene graph, which is drawn on the world layer on
+// every frame before the shapes passed to Draw. Pass nil to stop drawing the scene.
+func (w *Window) SetScene(root *Node) {
+	w.Layer(LayerWorld).SetScene(root)
+}
+
+// Scene returns the root node of the world layer's scene graph, or nil if it doesn't
+// have one.
+func (w *Window) Scene() *Node {
+	return w.Layer(LayerWorld).Scene()
+}
+
+// SetCamera sets the camera which the world layer is seen through, so that shapes passed
+// to Draw are positioned in world coordinates. Drawables which can't be transformed, such
+// as buttons, are still drawn in window coordinates, unless they are in a scene graph. Pass
+// nil to draw everything in window coordinates.
+func (w *Window) SetCamera(c *Camera) {
+	w.Layer(LayerWorld).SetCamera(c)
+}
+
+// Camera returns the world layer's camera, or nil if it doesn't have one.
+func (w *Window) Camera() *Camera {
+	return w.Layer(LayerWorld).Camera()
+}
+
+// WorldToScreen maps a point in the world to a point on the window, through the world
+// layer's camera.
+func (w *Window) WorldToScreen(p Vec) Vec {
+	if c := w.Camera(); c != nil {
+		return c.WorldToScreen(p)
+	}
+	return p
+}
+
+// ScreenToWorld maps a point on the window to a point in the world, through the world
+// layer's camera.
+func (w *Window) ScreenToWorld(p Vec) Vec {
+	if c := w.Camera(); c != nil {
+		return c.ScreenToWorld(p)
+	}
+	return p
+}
+
+// MouseWorldLocation returns the location of the mouse cursor in the world, as seen
+// through the world layer's camera.
+func (w *Window) MouseWorldLocation() Vec {
+	return w.ScreenToWorld(w.MouseLocation())
 }
 
 // MouseState represents the state of the mouse buttons.
@@ -234,37 +298,31 @@ func (m MouseState) String() string {
 
 // MouseButtonState returns the current state of the mouse buttons.
 func (w *Window) MouseButtonState() MouseState {
-	return MouseState(sdl.GetMouseState(nil, nil))
+	_, state := w.backend.Mouse()
+	return state
 }
 
 // Width returns the width of the window in pixels.
 func (w *Window) Width() int {
-	var width int32
-	if !sdl.GetWindowSize(w.win, &width, nil) {
-		fmt.Println("failed to get window size: " + sdl.GetError())
-	}
-	return int(width)
+	width, _ := w.backend.Size()
+	return width
 }
 
 // Height returns the height of the window in pixels.
 func (w *Window) Height() int {
-	var height int32
-	if !sdl.GetWindowSize(w.win, nil, &height) {
-		fmt.Println("failed to get window size: " + sdl.GetError())
-	}
-	return int(height)
+	_, height := w.backend.Size()
+	return height
 }
 
 // SetTitle sets the title of the window to the provided string.
 func (w *Window) SetTitle(title string) {
-	if !sdl.SetWindowTitle(w.win, title) {
-		fmt.Println("failed to set window title: " + sdl.GetError())
+	if err := w.backend.SetTitle(title); err != nil {
+		fmt.Println(err)
 	}
 }
 
 // engine contains constructs used to execute background logic.
 type engine struct {
-	drawQueue          []Drawable
 	running            bool
 	keyTracker         *keyTracker
 	mouseScrollTracker *mouseScrollHandler
@@ -274,7 +332,6 @@ type engine struct {
 // newEngine constructs a new gogl engine.
 func newEngine() *engine {
 	return &engine{
-		drawQueue:          []Drawable{},
 		running:            true,
 		keyTracker:         newKeyTracker(),
 		mouseScrollTracker: newMouseScrollHandler(),
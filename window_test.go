@@ -0,0 +1,144 @@
+package gogl
+
+import (
+	"testing"
+)
+
+// newHeadlessWindow constructs a window with a headless backend for testing.
+func newHeadlessWindow(t *testing.T, width, height int) (*Window, *HeadlessBackend) {
+	t.Helper()
+
+	backend := NewHeadlessBackend()
+	win, err := NewWindow(WindowCfg{Width: width, Height: height, Backend: backend})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(win.Destroy)
+
+	return win, backend
+}
+
+func TestHeadlessWindowUpdate(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 40, 30)
+
+	win.SetBackground(Black)
+	win.Draw(NewRect(9, 9, Vec{10, 10}).SetStyle(Style{Colour: Red}))
+	win.Update()
+
+	if backend.FramesPresented() != 1 {
+		t.Fatalf("Expected 1 frame to be presented, got %d", backend.FramesPresented())
+	}
+	if got := backend.Frame().GetPixel(15, 15); got != NewPixel(Red) {
+		t.Errorf("Expected pixel inside rectangle to be %v, got %v", NewPixel(Red), got)
+	}
+	if got := backend.Frame().GetPixel(5, 5); got != NewPixel(Black) {
+		t.Errorf("Expected pixel outside rectangle to be %v, got %v", NewPixel(Black), got)
+	}
+	if w, h := win.Width(), win.Height(); w != 40 || h != 30 {
+		t.Errorf("Expected window size 40x30, got %dx%d", w, h)
+	}
+
+	backend.QueueEvents(QuitEvent{})
+	win.Update()
+	if win.IsRunning() {
+		t.Error("Expected window to stop running after quit event")
+	}
+}
+
+func TestHeadlessKeybinds(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 10, 10)
+
+	var pressed, released, held int
+	win.RegisterKeybind(KeyA, KeyPress, func() { pressed++ })
+	win.RegisterKeybind(KeyA, KeyRelease, func() { released++ })
+	win.RegisterKeybind(KeyA, Instantaneous, func() { held++ })
+
+	backend.QueueEvents(KeyEvent{Key: KeyA, Down: true})
+	win.Update()
+	backend.QueueEvents(KeyEvent{Key: KeyA, Down: true, Repeat: true})
+	win.Update()
+	if !win.KeyIsPressed(KeyA) {
+		t.Error("Expected key to be pressed")
+	}
+	backend.QueueEvents(KeyEvent{Key: KeyA, Down: false})
+	win.Update()
+	win.Update()
+
+	if pressed != 1 || released != 1 || held != 2 {
+		t.Errorf("Expected 1 press, 1 release and 2 holds, got %d, %d and %d", pressed, released, held)
+	}
+	if win.KeyIsPressed(KeyA) {
+		t.Error("Expected key to be released")
+	}
+}
+
+func TestHeadlessButton(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 100, 100)
+
+	var clicks int
+	button := NewButton(NewRect(20, 20, Vec{10, 10}), "fonts/luxisr.ttf").
+		SetCallback(ButtonTrigger{State: LeftClick, Behaviour: OnPress}, func() { clicks++ })
+
+	for _, step := range []struct {
+		pos   Vec
+		state MouseState
+	}{
+		{Vec{50, 50}, LeftClick}, // click outside the button
+		{Vec{15, 15}, NoClick},
+		{Vec{15, 15}, LeftClick}, // click inside the button
+		{Vec{15, 15}, LeftClick}, // hold inside the button
+		{Vec{15, 15}, NoClick},
+	} {
+		backend.SetMouse(step.pos, step.state)
+		win.Update()
+		button.Update(win)
+	}
+
+	if clicks != 1 {
+		t.Errorf("Expected 1 click, got %d", clicks)
+	}
+	if !button.IsHovering() {
+		t.Error("Expected button to be hovered")
+	}
+}
+
+func TestHeadlessTextBox(t *testing.T) {
+	win, backend := newHeadlessWindow(t, 100, 100)
+
+	var modified int
+	textBox := NewTextBox(NewRect(50, 20, Vec{10, 10}), "ab", "fonts/luxisr.ttf").
+		SetModifiedCB(func() { modified++ })
+
+	// Select the text box
+	backend.SetMouse(Vec{20, 20}, LeftClick)
+	win.Update()
+	textBox.Update(win)
+	if !textBox.IsEditing() {
+		t.Fatal("Expected text box to be in edit mode after click")
+	}
+
+	// Type into the text box
+	backend.SetMouse(Vec{20, 20}, NoClick)
+	backend.QueueEvents(
+		TextInputEvent{Text: "cd"},
+		KeyEvent{Key: KeyBackspace, Down: true},
+		KeyEvent{Key: KeyBackspace, Down: false},
+	)
+	win.Update()
+	textBox.Update(win)
+
+	if got := textBox.Text.Text(); got != "abc" {
+		t.Errorf("Expected text %q, got %q", "abc", got)
+	}
+	if modified != 1 {
+		t.Errorf("Expected 1 modification, got %d", modified)
+	}
+
+	// Deselect the text box
+	backend.SetMouse(Vec{90, 90}, LeftClick)
+	win.Update()
+	textBox.Update(win)
+	if textBox.IsEditing() {
+		t.Error("Expected text box to leave edit mode after clicking elsewhere")
+	}
+}